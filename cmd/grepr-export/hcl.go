@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+)
+
+// identifierPattern matches object keys that can be written unquoted in HCL.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// hclString returns s as a quoted HCL string literal. Template sequences are
+// escaped so that values such as grok patterns ("%{WORD:word}") are kept
+// literally instead of being interpreted as interpolations or directives.
+func hclString(s string) string {
+	quoted, _ := jobgraph.Marshal(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	quoted = strings.ReplaceAll(quoted, "%{", "%%{")
+	return quoted
+}
+
+// hclKey returns an object key, quoted only if necessary.
+func hclKey(key string) string {
+	if identifierPattern.MatchString(key) {
+		return key
+	}
+	return hclString(key)
+}
+
+// hclValue writes a JSON value as an HCL expression at the given indentation
+// depth. Object keys are ordered by objectKeys.
+func hclValue(b *strings.Builder, value interface{}, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("null")
+	case string:
+		b.WriteString(hclString(v))
+	case bool:
+		fmt.Fprintf(b, "%t", v)
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case json.Number:
+		b.WriteString(v.String())
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteString("[\n")
+		for _, elem := range v {
+			b.WriteString(indent + "  ")
+			hclValue(b, elem, depth+1)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "]")
+	case map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		// Like terraform fmt, align the equals signs of consecutive
+		// attributes; an attribute with a multi-line value ends the run.
+		keys := objectKeys(v)
+		values := make([]string, len(keys))
+		for i, k := range keys {
+			var vb strings.Builder
+			hclValue(&vb, v[k], depth+1)
+			values[i] = vb.String()
+		}
+		b.WriteString("{\n")
+		for start := 0; start < len(keys); {
+			end := start
+			for end < len(keys)-1 && !strings.Contains(values[end], "\n") {
+				end++
+			}
+			width := 0
+			for _, k := range keys[start : end+1] {
+				width = max(width, len(hclKey(k)))
+			}
+			for i := start; i <= end; i++ {
+				key := hclKey(keys[i])
+				fmt.Fprintf(b, "%s  %s%s = %s\n", indent, key, strings.Repeat(" ", width-len(key)), values[i])
+			}
+			start = end + 1
+		}
+		b.WriteString(indent + "}")
+	default:
+		// Values decoded from JSON are always one of the types above
+		b.WriteString(hclString(fmt.Sprint(v)))
+	}
+}
+
+// objectKeys returns the keys of an object with `type`, `name` and `vertices`
+// first and the rest sorted, so that vertices and job graphs read naturally.
+func objectKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if k != "type" && k != "name" && k != "vertices" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var first []string
+	for _, k := range []string{"type", "name", "vertices"} {
+		if _, ok := m[k]; ok {
+			first = append(first, k)
+		}
+	}
+	return append(first, keys...)
+}
@@ -0,0 +1,236 @@
+// Package main implements grepr-export, a command that writes Terraform
+// configuration for pipelines that already exist in a Grepr organization.
+//
+// For each live pipeline it writes a grepr_pipeline resource block, with the
+// job graph converted to a readable jsonencode({...}) expression, and an
+// import block, so that an organization can be onboarded to Terraform with a
+// single command followed by `terraform plan`.
+//
+// Usage:
+//
+//	grepr-export [-out dir] [-name-prefix prefix] [-include-managed] [-ownership-id id] [-force]
+//
+// The API host and credentials are read from the same environment variables
+// as the provider, with the same precedence: GREPR_HOST, then either
+// GREPR_ACCESS_TOKEN or GREPR_ACCESS_TOKEN_FILE, or else GREPR_CLIENT_ID and
+// GREPR_CLIENT_SECRET with the optional GREPR_AUTH0_DOMAIN.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/resources/pipeline"
+)
+
+// exportOptions holds the command line options.
+type exportOptions struct {
+	outDir         string
+	namePrefix     string
+	includeManaged bool
+	ownershipID    string
+	force          bool
+}
+
+func main() {
+	var opts exportOptions
+	flag.StringVar(&opts.outDir, "out", ".", "directory to write one .tf file per pipeline to")
+	flag.StringVar(&opts.namePrefix, "name-prefix", "", "only export pipelines whose name starts with this prefix")
+	flag.BoolVar(&opts.includeManaged, "include-managed", false, "also export pipelines created by Terraform (tagged "+pipeline.OwnershipTagKey+")")
+	flag.StringVar(&opts.ownershipID, "ownership-id", "", "only treat pipelines created with this provider ownership_id as managed, so that pipelines of other owners are exported")
+	flag.BoolVar(&opts.force, "force", false, "overwrite existing files")
+	flag.Parse()
+
+	cfg, err := clientConfigFromEnv(os.Getenv)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	count, err := export(context.Background(), client.NewClient(cfg), opts)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	log.Printf("Exported %d pipelines to %s", count, opts.outDir)
+}
+
+// clientConfigFromEnv returns the client configuration from the provider's
+// environment variables. Like the provider, it uses GREPR_ACCESS_TOKEN or
+// GREPR_ACCESS_TOKEN_FILE if one is set, and otherwise requires the client
+// credentials for Auth0; client.NewClient then picks the matching token source.
+func clientConfigFromEnv(getenv func(string) string) (client.Config, error) {
+	cfg := client.Config{
+		Host:            getenv("GREPR_HOST"),
+		ClientID:        getenv("GREPR_CLIENT_ID"),
+		ClientSecret:    getenv("GREPR_CLIENT_SECRET"),
+		AccessToken:     getenv("GREPR_ACCESS_TOKEN"),
+		AccessTokenFile: getenv("GREPR_ACCESS_TOKEN_FILE"),
+		Auth0Domain:     getenv("GREPR_AUTH0_DOMAIN"),
+	}
+
+	switch {
+	case cfg.Host == "":
+		return cfg, errors.New("GREPR_HOST must be set")
+	case cfg.AccessToken != "" && cfg.AccessTokenFile != "":
+		return cfg, errors.New("only one of GREPR_ACCESS_TOKEN and GREPR_ACCESS_TOKEN_FILE may be set")
+	case cfg.AccessToken != "" || cfg.AccessTokenFile != "":
+		return cfg, nil
+	case cfg.ClientID == "" || cfg.ClientSecret == "":
+		return cfg, errors.New("GREPR_CLIENT_ID and GREPR_CLIENT_SECRET must be set, unless GREPR_ACCESS_TOKEN or GREPR_ACCESS_TOKEN_FILE is set")
+	}
+	return cfg, nil
+}
+
+// export writes a .tf file for each matching pipeline and returns how many
+// were written. Pipelines whose names grepr_pipeline would reject are skipped
+// with a warning, since their configuration would not pass terraform validate.
+func export(ctx context.Context, c *client.Client, opts exportOptions) (int, error) {
+	if err := os.MkdirAll(opts.outDir, 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	count := 0
+	labels := make(map[string]bool)
+	for job, err := range c.Jobs(ctx, client.ListJobsOptions{}) {
+		if err != nil {
+			return count, err
+		}
+		if client.IsTerminal(job.State) || !strings.HasPrefix(job.Name, opts.namePrefix) {
+			continue
+		}
+		if !opts.includeManaged && isManaged(&job, opts.ownershipID) {
+			continue
+		}
+		if !pipeline.IsValidName(job.Name) {
+			log.Printf("Skipping pipeline %q (ID: %s): grepr_pipeline names may only contain lowercase letters, numbers and underscores "+
+				"and be 1-128 characters long. Rename the pipeline in Grepr and run grepr-export again.", job.Name, job.Id)
+			continue
+		}
+
+		// The label also names the file, so it must be unique
+		label := resourceLabel(job.Name)
+		for i := 2; labels[label]; i++ {
+			label = fmt.Sprintf("%s_%d", resourceLabel(job.Name), i)
+		}
+		labels[label] = true
+
+		config, err := pipelineConfig(&job, label)
+		if err != nil {
+			return count, fmt.Errorf("pipeline %s: %w", job.Name, err)
+		}
+
+		path := filepath.Join(opts.outDir, label+".tf")
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if !opts.force {
+			flags |= os.O_EXCL
+		}
+		f, err := os.OpenFile(path, flags, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			return count, fmt.Errorf("%s already exists, use -force to overwrite", path)
+		}
+		if err != nil {
+			return count, err
+		}
+		_, err = f.WriteString(config)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+
+		count++
+	}
+	return count, nil
+}
+
+// isManaged reports whether a pipeline was created by Terraform: by the
+// provider configuration with the given ownership_id if one is given, or by
+// any provider configuration otherwise.
+func isManaged(job *client.Job, ownershipID string) bool {
+	owner, ok := job.Tags[pipeline.OwnershipTagKey]
+	if ownershipID == "" {
+		return ok
+	}
+	return ok && owner == pipeline.OwnershipTagValue(ownershipID)
+}
+
+// resourceLabel returns the resource name, and file name, for a pipeline name.
+// Pipeline names are not restricted by the API, so every character outside
+// [a-z0-9_] is lowercased or replaced with an underscore, which also keeps
+// names such as "../x" from escaping the output directory even if they were
+// not skipped. Labels that would be empty or start with a digit are prefixed
+// with "pipeline_".
+func resourceLabel(name string) string {
+	label := strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "pipeline_" + label
+	}
+	return label
+}
+
+// pipelineConfig returns the import and resource blocks for a pipeline, using
+// label as the resource name.
+func pipelineConfig(job *client.Job, label string) (string, error) {
+	graph, err := jobgraph.Decode(&job.JobGraph)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "import {\n  to = grepr_pipeline.%s\n  id = %s\n}\n\n", label, hclString("id:"+job.Id))
+	fmt.Fprintf(&b, "resource \"grepr_pipeline\" %s {\n", hclString(label))
+	fmt.Fprintf(&b, "  name          = %s\n", hclString(job.Name))
+	fmt.Fprintf(&b, "  desired_state = %s\n", hclString(string(job.DesiredState)))
+
+	if job.TeamIds != nil && len(*job.TeamIds) > 0 {
+		teamIDs := make([]interface{}, 0, len(*job.TeamIds))
+		for _, id := range *job.TeamIds {
+			teamIDs = append(teamIDs, id)
+		}
+		b.WriteString("\n  team_ids = ")
+		hclValue(&b, teamIDs, 1)
+		b.WriteString("\n")
+	}
+
+	tags := make(map[string]interface{}, len(job.Tags))
+	for k, v := range job.Tags {
+		if k != pipeline.OwnershipTagKey && k != client.IdempotencyKeyTag {
+			tags[k] = v
+		}
+	}
+	if len(tags) > 0 {
+		b.WriteString("\n  tags = ")
+		hclValue(&b, tags, 1)
+		b.WriteString("\n")
+	}
+
+	edges := make([]interface{}, 0, len(graph.Edges))
+	for _, e := range graph.Edges {
+		edges = append(edges, jobgraph.NormalizeEdge(e))
+	}
+	vertices := make([]interface{}, 0, len(graph.Vertices))
+	for _, v := range graph.Vertices {
+		vertices = append(vertices, v)
+	}
+
+	b.WriteString("\n  job_graph_json = jsonencode(")
+	hclValue(&b, map[string]interface{}{"vertices": vertices, "edges": edges}, 1)
+	b.WriteString(")\n}\n")
+
+	return b.String(), nil
+}
@@ -0,0 +1,258 @@
+// Package main provides unit tests for the HCL generated by grepr-export.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+)
+
+// TestPipelineConfig verifies the import and resource blocks written for a
+// pipeline, including escaping of template sequences in grok patterns.
+func TestPipelineConfig(t *testing.T) {
+	var job client.Job
+	err := json.Unmarshal([]byte(`{
+		"id": "0ABC12DEF4G",
+		"name": "ui_pipeline",
+		"desiredState": "RUNNING",
+		"tags": {"team": "logs", "cost-center": "42", "terraform_managed": "true"},
+		"teamIds": ["team-1"],
+		"jobGraph": {
+			"vertices": [
+				{"name": "source", "type": "datadog-log-agent-source", "integrationId": "abc"},
+				{"type": "grok-parser", "name": "parser", "grokParsingRules": ["%{WORD:word} ${x}"]}
+			],
+			"edges": ["source->parser"]
+		}
+	}`), &job)
+	if err != nil {
+		t.Fatalf("failed to decode job: %v", err)
+	}
+
+	got, err := pipelineConfig(&job, resourceLabel(job.Name))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `import {
+  to = grepr_pipeline.ui_pipeline
+  id = "id:0ABC12DEF4G"
+}
+
+resource "grepr_pipeline" "ui_pipeline" {
+  name          = "ui_pipeline"
+  desired_state = "RUNNING"
+
+  team_ids = [
+    "team-1",
+  ]
+
+  tags = {
+    cost-center = "42"
+    team        = "logs"
+  }
+
+  job_graph_json = jsonencode({
+    vertices = [
+      {
+        type          = "datadog-log-agent-source"
+        name          = "source"
+        integrationId = "abc"
+      },
+      {
+        type             = "grok-parser"
+        name             = "parser"
+        grokParsingRules = [
+          "%%{WORD:word} $${x}",
+        ]
+      },
+    ]
+    edges = [
+      "source -> parser",
+    ]
+  })
+}
+`
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+// TestResourceLabel verifies that pipeline names are turned into valid
+// resource and file names.
+func TestResourceLabel(t *testing.T) {
+	tests := map[string]string{
+		"ui_pipeline":    "ui_pipeline",
+		"Prod-Logs":      "prod_logs",
+		"my pipeline.v2": "my_pipeline_v2",
+		"../../etc/x":    "______etc_x",
+		`a\b`:            "a_b",
+		"1st":            "pipeline_1st",
+		"":               "pipeline_",
+		"caf\u00e9":      "caf_",
+		"quote\"${x}\"":  "quote___x__",
+	}
+	for name, expected := range tests {
+		if got := resourceLabel(name); got != expected {
+			t.Errorf("resourceLabel(%q) = %s, expected %s", name, got, expected)
+		}
+	}
+}
+
+// TestExport verifies that every pipeline is written to a file named after its
+// unique resource label inside the output directory, and that pipelines whose
+// names grepr_pipeline would reject are skipped with a warning.
+func TestExport(t *testing.T) {
+	jobs := []client.Job{
+		{Id: "job-1", Name: "../escape", DesiredState: "RUNNING"},
+		{Id: "job-2", Name: "Prod-Logs", DesiredState: "RUNNING"},
+		{Id: "job-3", Name: "prod_logs", DesiredState: "RUNNING"},
+		{Id: "job-4", Name: "1st", DesiredState: "RUNNING"},
+		{Id: "job-5", Name: "pipeline_1st", DesiredState: "RUNNING"},
+		{Id: "job-6", Name: "deleted", State: client.JobStateDeleted},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.JobsResponse{Items: &jobs})
+	}))
+	defer server.Close()
+	c := client.NewClient(client.Config{Host: server.URL, AccessToken: "test-token"})
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	outDir := filepath.Join(t.TempDir(), "out")
+	count, err := export(context.Background(), c, exportOptions{outDir: outDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 pipelines, got %d", count)
+	}
+
+	for _, skipped := range []string{`"../escape" (ID: job-1)`, `"Prod-Logs" (ID: job-2)`} {
+		if !strings.Contains(logs.String(), "Skipping pipeline "+skipped) {
+			t.Errorf("expected a warning for pipeline %s, got:\n%s", skipped, logs.String())
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(outDir))
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "out" {
+		t.Errorf("expected only the output directory, got %v", entries)
+	}
+
+	entries, err = os.ReadDir(outDir)
+	if err != nil {
+		t.Fatalf("failed to read output directory: %v", err)
+	}
+	var files []string
+	for _, e := range entries {
+		files = append(files, e.Name())
+	}
+	expected := []string{"pipeline_1st.tf", "pipeline_1st_2.tf", "prod_logs.tf"}
+	if !slices.Equal(files, expected) {
+		t.Errorf("expected files %v, got %v", expected, files)
+	}
+
+	config, err := os.ReadFile(filepath.Join(outDir, "pipeline_1st_2.tf"))
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(config), `resource "grepr_pipeline" "pipeline_1st_2"`) || !strings.Contains(string(config), `name          = "pipeline_1st"`) {
+		t.Errorf("expected the unique label and the original name, got:\n%s", config)
+	}
+}
+
+// TestClientConfigFromEnv verifies that grepr-export accepts the same
+// credential environment variables as the provider.
+func TestClientConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		expectError bool
+	}{
+		{"client credentials", map[string]string{"GREPR_HOST": "https://h", "GREPR_CLIENT_ID": "id", "GREPR_CLIENT_SECRET": "secret"}, false},
+		{"access token", map[string]string{"GREPR_HOST": "https://h", "GREPR_ACCESS_TOKEN": "token"}, false},
+		{"access token file", map[string]string{"GREPR_HOST": "https://h", "GREPR_ACCESS_TOKEN_FILE": "/token"}, false},
+		{"missing host", map[string]string{"GREPR_ACCESS_TOKEN": "token"}, true},
+		{"both tokens", map[string]string{"GREPR_HOST": "https://h", "GREPR_ACCESS_TOKEN": "token", "GREPR_ACCESS_TOKEN_FILE": "/token"}, true},
+		{"missing client secret", map[string]string{"GREPR_HOST": "https://h", "GREPR_CLIENT_ID": "id"}, true},
+		{"no credentials", map[string]string{"GREPR_HOST": "https://h"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := clientConfigFromEnv(func(key string) string { return tt.env[key] })
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Host != tt.env["GREPR_HOST"] || cfg.AccessToken != tt.env["GREPR_ACCESS_TOKEN"] ||
+				cfg.AccessTokenFile != tt.env["GREPR_ACCESS_TOKEN_FILE"] || cfg.ClientID != tt.env["GREPR_CLIENT_ID"] {
+				t.Errorf("expected the configuration from the environment, got %+v", cfg)
+			}
+		})
+	}
+}
+
+// TestHCLKey verifies that only keys that are not valid identifiers are quoted.
+func TestHCLKey(t *testing.T) {
+	tests := map[string]string{
+		"integrationId": "integrationId",
+		"cost-center":   "cost-center",
+		"1st":           `"1st"`,
+		"a.b":           `"a.b"`,
+	}
+	for key, expected := range tests {
+		if got := hclKey(key); got != expected {
+			t.Errorf("hclKey(%q) = %s, expected %s", key, got, expected)
+		}
+	}
+}
+
+// TestIsManaged verifies which pipelines are skipped as created by Terraform,
+// with and without an ownership ID.
+func TestIsManaged(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        map[string]string
+		ownershipID string
+		expected    bool
+	}{
+		{"untagged", map[string]string{"env": "prod"}, "", false},
+		{"default owner", map[string]string{"terraform_managed": "true"}, "", true},
+		{"any owner", map[string]string{"terraform_managed": "workspace-a"}, "", true},
+		{"same owner", map[string]string{"terraform_managed": "workspace-a"}, "workspace-a", true},
+		{"other owner", map[string]string{"terraform_managed": "workspace-b"}, "workspace-a", false},
+		{"default owner with ownership ID", map[string]string{"terraform_managed": "true"}, "workspace-a", false},
+		{"untagged with ownership ID", nil, "workspace-a", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &client.Job{Name: "p", Tags: tt.tags}
+			if got := isManaged(job, tt.ownershipID); got != tt.expected {
+				t.Errorf("isManaged() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Package main implements grepr-render, a small command that renders the
+// topology of a Grepr job graph as Graphviz DOT or a Mermaid flowchart.
+//
+// It is intended for CI, e.g. to post a pipeline diagram as a pull request
+// comment. The input is a job graph JSON document, such as the value of
+// job_graph_json, or a job as returned by the Grepr API, in which case its
+// jobGraph is rendered.
+//
+// Usage:
+//
+//	grepr-render [-format mermaid|dot] [file]
+//
+// The graph is read from file, or from standard input if no file is given.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+)
+
+func main() {
+	var format string
+	flag.StringVar(&format, "format", jobgraph.FormatMermaid, "output format: "+strings.Join(jobgraph.Formats, " or "))
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-format mermaid|dot] [file]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	input, err := readInput(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	graph, err := parseInput(input)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	output, err := jobgraph.Render(graph, format)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	fmt.Print(output)
+}
+
+// readInput reads the named file, or standard input if name is empty or "-".
+func readInput(name string) ([]byte, error) {
+	if name == "" || name == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(name)
+}
+
+// parseInput decodes either a job graph or a job wrapping one under jobGraph.
+func parseInput(input []byte) (*jobgraph.Graph, error) {
+	var job struct {
+		JobGraph json.RawMessage `json:"jobGraph"`
+	}
+	if err := json.Unmarshal(input, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode input: %w", err)
+	}
+	if len(job.JobGraph) > 0 {
+		input = job.JobGraph
+	}
+	return jobgraph.Parse(string(input))
+}
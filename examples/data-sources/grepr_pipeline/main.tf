@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    grepr = {
+      source = "grepr-ai/grepr"
+    }
+  }
+}
+
+provider "grepr" {
+  # Configure via environment variables:
+  # GREPR_HOST, GREPR_CLIENT_ID, GREPR_CLIENT_SECRET
+}
+
+# Look up a pipeline managed elsewhere by name (or by id)
+data "grepr_pipeline" "shared" {
+  name = var.pipeline_name
+}
+
+variable "pipeline_name" {
+  description = "The name of the pipeline to look up"
+  type        = string
+}
+
+output "pipeline_id" {
+  description = "The ID of the pipeline"
+  value       = data.grepr_pipeline.shared.id
+}
+
+output "pipeline_state" {
+  description = "The current state of the pipeline"
+  value       = data.grepr_pipeline.shared.state
+}
+
+output "pipeline_vertices" {
+  description = "The names of the pipeline's vertices"
+  value       = [for v in jsondecode(data.grepr_pipeline.shared.job_graph_json).vertices : v.name]
+}
@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    grepr = {
+      source = "grepr-ai/grepr"
+    }
+  }
+}
+
+provider "grepr" {
+  # Configure via environment variables:
+  # GREPR_HOST, GREPR_CLIENT_ID, GREPR_CLIENT_SECRET
+}
+
+# List all running production pipelines whose name starts with "prod_"
+data "grepr_pipelines" "production" {
+  name_prefix = "prod_"
+  state       = "RUNNING"
+
+  tags = {
+    environment = "production"
+  }
+}
+
+output "production_pipeline_ids" {
+  description = "The IDs of the matching pipelines, keyed by name"
+  value       = { for p in data.grepr_pipelines.production.pipelines : p.name => p.id }
+}
@@ -0,0 +1,48 @@
+terraform {
+  required_version = ">= 1.8"
+
+  required_providers {
+    grepr = {
+      source = "grepr-ai/grepr"
+    }
+  }
+}
+
+provider "grepr" {
+  # Configure via environment variables:
+  # GREPR_HOST, GREPR_CLIENT_ID, GREPR_CLIENT_SECRET
+}
+
+variable "integration_id" {
+  description = "The Datadog integration ID"
+  type        = string
+}
+
+variable "dataset_id" {
+  description = "The dataset ID to write parsed logs to"
+  type        = string
+}
+
+locals {
+  vertices = [
+    provider::grepr::datadog_source("source", var.integration_id),
+    provider::grepr::grok_parser("parser", ["%%{TIMESTAMP_ISO8601:timestamp} %%{LOGLEVEL:level} %%{GREEDYDATA:message}"]),
+    provider::grepr::iceberg_sink("sink", var.dataset_id),
+  ]
+
+  edges = [
+    provider::grepr::edge("source", "parser"),
+    provider::grepr::edge("parser", "sink"),
+  ]
+}
+
+# Build the job graph from the vertices and edges, and fail at plan time if it is invalid
+resource "grepr_pipeline" "composed" {
+  name           = "composed_pipeline"
+  job_graph_json = provider::grepr::validate_graph(provider::grepr::graph(local.vertices, local.edges))
+}
+
+output "normalized_job_graph" {
+  description = "The canonical form of the pipeline's job graph"
+  value       = provider::grepr::normalize_graph(grepr_pipeline.composed.job_graph_json)
+}
@@ -0,0 +1,14 @@
+terraform {
+  required_version = ">= 1.14"
+
+  required_providers {
+    grepr = {
+      source = "grepr-ai/grepr"
+    }
+  }
+}
+
+provider "grepr" {
+  # Configure via environment variables:
+  # GREPR_HOST, GREPR_CLIENT_ID, GREPR_CLIENT_SECRET
+}
@@ -0,0 +1,15 @@
+# List all production pipelines whose name starts with "prod_".
+#
+#   terraform query
+#   terraform query -generate-config-out=generated.tf
+list "grepr_pipeline" "production" {
+  provider = grepr
+
+  config {
+    name_prefix = "prod_"
+
+    tags = {
+      environment = "production"
+    }
+  }
+}
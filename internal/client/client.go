@@ -1,8 +1,10 @@
 // Package client provides a Go client for the Grepr API.
 //
-// The client handles OAuth2 authentication via Auth0 and provides methods for
-// managing async streaming jobs (pipelines). It includes automatic token caching
-// and refresh, as well as helper methods for waiting on job state transitions.
+// The client authenticates with tokens from a pluggable TokenSource: OAuth2
+// client credentials via Auth0 by default, or a static, file-based or
+// command-provided bearer token. It provides methods for managing async
+// streaming jobs (pipelines). It includes automatic token caching and refresh,
+// as well as helper methods for waiting on job state transitions.
 //
 // Basic usage:
 //
@@ -43,21 +45,24 @@ const (
 
 // Client is the Grepr API client.
 //
-// It handles OAuth2 authentication and provides methods for CRUD operations on jobs.
+// It authenticates with tokens from its TokenSource and provides methods for CRUD operations on jobs.
 // The client is safe for concurrent use - token caching uses a read-write mutex to
 // allow multiple concurrent API calls while ensuring thread-safe token refresh.
 type Client struct {
-	httpClient   *http.Client
-	host         string
-	clientID     string
-	clientSecret string
-	auth0Domain  string
+	httpClient *http.Client
+	host       string
+
+	// tokenSource supplies access tokens when the cached token is missing or expired.
+	tokenSource TokenSource
 
 	// Token caching fields. Protected by tokenMu for thread-safe access.
-	// We cache the token and refresh it before expiry to minimize Auth0 calls.
+	// We cache the token and refresh it before expiry to minimize token source calls.
 	tokenMu     sync.RWMutex
 	accessToken string
 	tokenExpiry time.Time
+
+	// retryPolicy controls retries in doRequest. If nil, DefaultRetryPolicy is used.
+	retryPolicy *RetryPolicy
 }
 
 // Config contains the configuration for creating a new Client.
@@ -66,23 +71,54 @@ type Config struct {
 	ClientID     string
 	ClientSecret string
 	Auth0Domain  string
+
+	// RetryPolicy controls how failed requests are retried.
+	// If nil, DefaultRetryPolicy() is used.
+	RetryPolicy *RetryPolicy
+
+	// AccessToken, if set, is sent as the bearer token of every request
+	// instead of a token obtained from Auth0 with ClientID and ClientSecret.
+	AccessToken string
+
+	// AccessTokenFile, if set, is the path of a file containing the bearer
+	// token. The file is re-read whenever it changes, so that a token rotated
+	// by another process is picked up. It is ignored if AccessToken is set.
+	AccessTokenFile string
+
+	// TokenSource, if set, supplies the access tokens instead of Auth0,
+	// AccessToken or AccessTokenFile.
+	TokenSource TokenSource
+
+	// HTTPClient is used for both Grepr API calls and the Auth0 token request.
+	// If nil, a client with the default TransportConfig is used. See NewHTTPClient.
+	HTTPClient *http.Client
 }
 
 // NewClient creates a new Grepr API client.
 func NewClient(cfg Config) *Client {
-	auth0Domain := cfg.Auth0Domain
-	if auth0Domain == "" {
-		auth0Domain = defaultAuth0Domain
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: defaultRequestTimeout,
+		}
+	}
+
+	tokenSource := cfg.TokenSource
+	switch {
+	case tokenSource != nil:
+	case cfg.AccessToken != "":
+		tokenSource = NewStaticTokenSource(cfg.AccessToken)
+	case cfg.AccessTokenFile != "":
+		tokenSource = NewFileTokenSource(cfg.AccessTokenFile)
+	default:
+		tokenSource = NewAuth0TokenSource(httpClient, cfg.Auth0Domain, cfg.ClientID, cfg.ClientSecret)
 	}
 
 	return &Client{
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		host:         cfg.Host,
-		clientID:     cfg.ClientID,
-		clientSecret: cfg.ClientSecret,
-		auth0Domain:  auth0Domain,
+		httpClient:  httpClient,
+		host:        cfg.Host,
+		tokenSource: tokenSource,
+		retryPolicy: cfg.RetryPolicy,
 	}
 }
 
@@ -91,10 +127,11 @@ func NewClient(cfg Config) *Client {
 // This method uses a double-checked locking pattern:
 // 1. First, acquire a read lock and check if we have a valid cached token
 // 2. If not, acquire a write lock and check again (another goroutine may have refreshed)
-// 3. If still needed, fetch a new token from Auth0
+// 3. If still needed, fetch a new token from the client's TokenSource
 //
 // This allows multiple goroutines to use a cached token concurrently while
-// ensuring only one goroutine refreshes the token when needed.
+// ensuring only one goroutine refreshes the token when needed. Tokens without
+// an expiry are never considered valid, so their source is asked every time.
 func (c *Client) getToken(ctx context.Context) (string, error) {
 	// Fast path: check with read lock if we have a valid cached token
 	c.tokenMu.RLock()
@@ -114,72 +151,69 @@ func (c *Client) getToken(ctx context.Context) (string, error) {
 		return c.accessToken, nil
 	}
 
-	token, expiresIn, err := c.FetchToken(ctx)
+	if c.tokenSource == nil {
+		return "", fmt.Errorf("no token source configured")
+	}
+	token, err := fetchToken(ctx, c.tokenSource)
 	if err != nil {
 		return "", err
 	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token source returned an empty access token")
+	}
 
-	c.accessToken = token
-	c.tokenExpiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	c.accessToken = token.AccessToken
+	c.tokenExpiry = token.Expiry
 
-	return token, nil
+	return token.AccessToken, nil
 }
 
-// FetchToken fetches a new OAuth token from Auth0.
+// FetchToken fetches a new access token from the client's TokenSource,
+// bypassing the client's token cache. It returns the token and the number of
+// seconds until it expires, which is 0 for tokens without a known expiry.
+//
+// Deprecated: FetchToken predates TokenSource and is kept for compatibility.
+// Configure the client with a TokenSource via Config.TokenSource and call it
+// directly instead.
 func (c *Client) FetchToken(ctx context.Context) (string, int, error) {
-	tokenURL := fmt.Sprintf("https://%s/oauth/token", c.auth0Domain)
-
-	reqBody := OAuthTokenRequest{
-		ClientID:     c.clientID,
-		ClientSecret: c.clientSecret,
-		Audience:     "service",
-		GrantType:    "client_credentials",
-	}
-
-	body, err := json.Marshal(reqBody)
-	if err != nil {
-		return "", 0, fmt.Errorf("failed to marshal token request: %w", err)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(body))
-	if err != nil {
-		return "", 0, fmt.Errorf("failed to create token request: %w", err)
+	if c.tokenSource == nil {
+		return "", 0, fmt.Errorf("no token source configured")
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+	token, err := fetchToken(ctx, c.tokenSource)
 	if err != nil {
-		return "", 0, fmt.Errorf("failed to fetch token: %w", err)
+		return "", 0, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", 0, fmt.Errorf("failed to fetch token: status %d", resp.StatusCode)
+	expiresIn := 0
+	if !token.Expiry.IsZero() {
+		expiresIn = max(int(time.Until(token.Expiry).Seconds()), 0)
 	}
+	return token.AccessToken, expiresIn, nil
+}
 
-	var tokenResp OAuthTokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", 0, fmt.Errorf("failed to decode token response: %w", err)
+// retryPolicyOrDefault returns the client's retry policy, or the default policy if none is set.
+func (c *Client) retryPolicyOrDefault() RetryPolicy {
+	if c.retryPolicy == nil {
+		return DefaultRetryPolicy()
 	}
-
-	return tokenResp.AccessToken, tokenResp.ExpiresIn, nil
+	return *c.retryPolicy
 }
 
-const (
-	// maxRetries is the maximum number of retry attempts for retryable errors (5xx).
-	maxRetries = 3
-	// initialRetryDelay is the initial delay between retries (exponential backoff).
-	initialRetryDelay = 100 * time.Millisecond
-	// maxRetryDelay is the maximum delay between retries.
-	maxRetryDelay = 5 * time.Second
-)
-
-// doRequest performs an authenticated HTTP request with retry logic for server errors.
-// It will retry up to maxRetries times for 5xx errors with exponential backoff.
-// Client errors (4xx) are not retried as they indicate a problem with the request.
+// doRequest performs an authenticated HTTP request, retrying transient
+// failures according to the client's RetryPolicy.
+//
+// Retries use exponential backoff with jitter, or the delay requested by the
+// response's Retry-After header if there is one. Waiting between retries stops
+// as soon as ctx is cancelled. Once the policy's attempts or total wait are
+// spent, the last response is returned to the caller. Client errors (other
+// 4xx) are not retried as they indicate a problem with the request.
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
-	var lastErr error
+	return c.doRequestWithHeader(ctx, method, path, body, nil)
+}
+
+// doRequestWithHeader is doRequest with additional request headers, such as
+// an idempotency key.
+func (c *Client) doRequestWithHeader(ctx context.Context, method, path string, body interface{}, header http.Header) (*http.Response, error) {
 	var jsonBody []byte
 
 	// Marshal body once before retries
@@ -191,8 +225,12 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		}
 	}
 
+	policy := c.retryPolicyOrDefault()
+	maxAttempts := max(policy.MaxAttempts, 1)
+	var waited time.Duration
+
 	// Retry loop with exponential backoff
-	for attempt := 0; attempt <= maxRetries; attempt++ {
+	for attempt := 0; ; attempt++ {
 		// Get fresh token for each attempt (in case it expired during retries)
 		token, err := c.getToken(ctx)
 		if err != nil {
@@ -214,49 +252,67 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Accept", "application/json")
+		for key, values := range header {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
-			// Network errors are retryable
-			lastErr = err
-			if attempt < maxRetries {
-				delay := calculateBackoff(attempt)
-				time.Sleep(delay)
-				continue
+			// Network errors are retryable for idempotent requests
+			delay := policy.backoff(attempt)
+			if ctx.Err() != nil || attempt+1 >= maxAttempts || !policy.canRetry(method, 0) ||
+				(policy.MaxWait > 0 && waited+delay > policy.MaxWait) {
+				return nil, &TransportError{Attempts: attempt + 1, Err: err}
 			}
-			return nil, fmt.Errorf("request failed after %d attempts: %w", maxRetries+1, err)
-		}
-
-		// Check if we should retry based on status code
-		if resp.StatusCode >= 500 && attempt < maxRetries {
-			// Server error - read body for error message, then retry
-			bodyBytes, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			lastErr = &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(bodyBytes),
+			waited += delay
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, fmt.Errorf("request failed after %d attempts: %w", attempt+1, err)
 			}
-			delay := calculateBackoff(attempt)
-			time.Sleep(delay)
 			continue
 		}
 
-		// Success or non-retryable error (4xx) - return response
-		return resp, nil
+		// Success or non-retryable error - return response
+		if attempt+1 >= maxAttempts || !policy.canRetry(method, resp.StatusCode) {
+			return resp, nil
+		}
+
+		delay, ok := retryAfter(resp.Header, time.Now())
+		if !ok {
+			delay = policy.backoff(attempt)
+		}
+		if policy.MaxWait > 0 && waited+delay > policy.MaxWait {
+			// The server asked us to wait longer than the budget allows,
+			// so give up now and let the caller handle the response.
+			return resp, nil
+		}
+
+		// Retryable error - discard the body, then retry
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		waited += delay
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, fmt.Errorf("request failed after %d attempts (status %d): %w", attempt+1, resp.StatusCode, err)
+		}
 	}
+}
 
-	// All retries exhausted
-	return nil, fmt.Errorf("request failed after %d attempts: %w", maxRetries+1, lastErr)
+// TransportError is returned when a request could not be completed because
+// of a network error. The request may or may not have reached the server.
+type TransportError struct {
+	// Attempts is the number of attempts made, including retries.
+	Attempts int
+	Err      error
 }
 
-// calculateBackoff calculates the retry delay using exponential backoff.
-// Formula: min(initialDelay * 2^attempt, maxDelay)
-func calculateBackoff(attempt int) time.Duration {
-	delay := initialRetryDelay * time.Duration(1<<uint(attempt))
-	if delay > maxRetryDelay {
-		delay = maxRetryDelay
-	}
-	return delay
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
 }
 
 // APIError represents an error from the Grepr API.
@@ -297,11 +353,18 @@ func (e *APIError) IsForbidden() bool {
 }
 
 // IsClientError returns true if the error is a 4xx client error.
-// Client errors indicate issues with the request that should not be retried.
+// Client errors indicate issues with the request that should not be retried,
+// except for 429 Too Many Requests (see IsRetryable).
 func (e *APIError) IsClientError() bool {
 	return e.StatusCode >= 400 && e.StatusCode < 500
 }
 
+// IsTooManyRequests returns true if the error is a 429 Too Many Requests error.
+// The API is rate limiting the client; the request may succeed later.
+func (e *APIError) IsTooManyRequests() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
 // IsServerError returns true if the error is a 5xx server error.
 // Server errors are transient and may succeed on retry.
 func (e *APIError) IsServerError() bool {
@@ -309,9 +372,9 @@ func (e *APIError) IsServerError() bool {
 }
 
 // IsRetryable returns true if the error might succeed on retry.
-// Only server errors (5xx) are considered retryable.
+// Rate limiting (429) and server errors (5xx) are considered retryable.
 func (e *APIError) IsRetryable() bool {
-	return e.IsServerError()
+	return e.IsTooManyRequests() || e.IsServerError()
 }
 
 // handleResponse processes an HTTP response and returns an error if not successful.
@@ -2,10 +2,12 @@ package client
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -24,14 +26,18 @@ func TestNewClient(t *testing.T) {
 	if c.host != cfg.Host {
 		t.Errorf("expected host %s, got %s", cfg.Host, c.host)
 	}
-	if c.clientID != cfg.ClientID {
-		t.Errorf("expected clientID %s, got %s", cfg.ClientID, c.clientID)
+	src, ok := c.tokenSource.(*auth0TokenSource)
+	if !ok {
+		t.Fatalf("expected an Auth0 token source, got %T", c.tokenSource)
 	}
-	if c.clientSecret != cfg.ClientSecret {
-		t.Errorf("expected clientSecret %s, got %s", cfg.ClientSecret, c.clientSecret)
+	if src.clientID != cfg.ClientID {
+		t.Errorf("expected clientID %s, got %s", cfg.ClientID, src.clientID)
 	}
-	if c.auth0Domain != defaultAuth0Domain {
-		t.Errorf("expected auth0Domain %s, got %s", defaultAuth0Domain, c.auth0Domain)
+	if src.clientSecret != cfg.ClientSecret {
+		t.Errorf("expected clientSecret %s, got %s", cfg.ClientSecret, src.clientSecret)
+	}
+	if src.domain != defaultAuth0Domain {
+		t.Errorf("expected auth0Domain %s, got %s", defaultAuth0Domain, src.domain)
 	}
 }
 
@@ -47,8 +53,8 @@ func TestNewClient_CustomAuth0Domain(t *testing.T) {
 
 	c := NewClient(cfg)
 
-	if c.auth0Domain != cfg.Auth0Domain {
-		t.Errorf("expected auth0Domain %s, got %s", cfg.Auth0Domain, c.auth0Domain)
+	if src := c.tokenSource.(*auth0TokenSource); src.domain != cfg.Auth0Domain {
+		t.Errorf("expected auth0Domain %s, got %s", cfg.Auth0Domain, src.domain)
 	}
 }
 
@@ -172,6 +178,16 @@ func TestAPIError(t *testing.T) {
 				"IsRetryable":   false,
 			},
 		},
+		{
+			name:       "429 Too Many Requests",
+			statusCode: 429,
+			checks: map[string]bool{
+				"IsTooManyRequests": true,
+				"IsClientError":     true,
+				"IsServerError":     false,
+				"IsRetryable":       true,
+			},
+		},
 		{
 			name:       "500 Internal Server Error",
 			statusCode: 500,
@@ -214,6 +230,9 @@ func TestAPIError(t *testing.T) {
 			if expected, ok := tt.checks["IsConflict"]; ok && err.IsConflict() != expected {
 				t.Errorf("IsConflict() = %v, expected %v", err.IsConflict(), expected)
 			}
+			if expected, ok := tt.checks["IsTooManyRequests"]; ok && err.IsTooManyRequests() != expected {
+				t.Errorf("IsTooManyRequests() = %v, expected %v", err.IsTooManyRequests(), expected)
+			}
 			if expected, ok := tt.checks["IsClientError"]; ok && err.IsClientError() != expected {
 				t.Errorf("IsClientError() = %v, expected %v", err.IsClientError(), expected)
 			}
@@ -227,126 +246,211 @@ func TestAPIError(t *testing.T) {
 	}
 }
 
-// TestClient_FetchToken verifies that the OAuth token fetch process sends the correct
-// request format to the Auth0 token endpoint.
-// Note: This test validates request format and token caching behavior.
-func TestClient_FetchToken(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			t.Errorf("expected POST, got %s", r.Method)
+// fakeTokenSource is a TokenSource that returns a token with the given
+// lifetime, numbered by the number of calls, e.g. "token-1".
+type fakeTokenSource struct {
+	lifetime time.Duration
+	calls    int
+}
+
+func (s *fakeTokenSource) Token() (*Token, error) {
+	s.calls++
+	return &Token{
+		AccessToken: fmt.Sprintf("token-%d", s.calls),
+		Expiry:      time.Now().Add(s.lifetime),
+	}, nil
+}
+
+// TestClient_TokenCaching verifies that getToken() returns the cached token
+// when it hasn't expired, avoiding unnecessary token source calls.
+func TestClient_TokenCaching(t *testing.T) {
+	src := &fakeTokenSource{lifetime: time.Hour}
+	c := &Client{
+		httpClient:  http.DefaultClient,
+		tokenSource: src,
+		accessToken: "cached-token",
+		tokenExpiry: time.Now().Add(time.Hour), // Token still valid for 1 hour
+	}
+
+	for range 3 {
+		token, err := c.getToken(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if r.URL.Path != "/oauth/token" {
-			t.Errorf("expected /oauth/token, got %s", r.URL.Path)
+		if token != "cached-token" {
+			t.Errorf("expected cached-token, got %s", token)
 		}
+	}
+	if src.calls != 0 {
+		t.Errorf("expected no token source calls, got %d", src.calls)
+	}
+}
 
-		var req OAuthTokenRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			t.Fatalf("failed to decode request: %v", err)
-		}
+// TestClient_TokenExpired verifies that getToken() fetches a new token when
+// the cached token has expired or is about to, and caches the new token.
+func TestClient_TokenExpired(t *testing.T) {
+	src := &fakeTokenSource{lifetime: time.Hour}
+	c := &Client{
+		httpClient:  http.DefaultClient,
+		tokenSource: src,
+		accessToken: "old-token",
+		tokenExpiry: time.Now().Add(tokenRefreshBuffer / 2), // Within the refresh buffer
+	}
 
-		if req.ClientID != "test-client-id" {
-			t.Errorf("expected client_id test-client-id, got %s", req.ClientID)
+	for range 2 {
+		token, err := c.getToken(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
-		if req.ClientSecret != "test-client-secret" {
-			t.Errorf("expected client_secret test-client-secret, got %s", req.ClientSecret)
+		if token != "token-1" {
+			t.Errorf("expected token-1, got %s", token)
 		}
-		if req.Audience != "service" {
-			t.Errorf("expected audience service, got %s", req.Audience)
-		}
-		if req.GrantType != "client_credentials" {
-			t.Errorf("expected grant_type client_credentials, got %s", req.GrantType)
+	}
+	if src.calls != 1 {
+		t.Errorf("expected 1 token source call, got %d", src.calls)
+	}
+}
+
+// TestClient_TokenWithoutExpiry verifies that tokens without an expiry are not
+// cached, so their source is asked before every request.
+func TestClient_TokenWithoutExpiry(t *testing.T) {
+	calls := 0
+	c := NewClient(Config{
+		Host: "http://localhost",
+		TokenSource: TokenSourceFunc(func() (*Token, error) {
+			calls++
+			return &Token{AccessToken: "token"}, nil
+		}),
+	})
+
+	for range 3 {
+		if _, err := c.getToken(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
 		}
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 token source calls, got %d", calls)
+	}
+}
+
+// TestClient_FetchToken verifies that the deprecated FetchToken() delegates to
+// the configured token source and reports the token's lifetime in seconds.
+func TestClient_FetchToken(t *testing.T) {
+	c := NewClient(Config{Host: "http://localhost", TokenSource: &fakeTokenSource{lifetime: time.Hour}})
+
+	token, expiresIn, err := c.FetchToken(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "token-1" {
+		t.Errorf("expected token-1, got %s", token)
+	}
+	if expiresIn < 3500 || expiresIn > 3600 {
+		t.Errorf("expected the token to expire in about 3600 seconds, got %d", expiresIn)
+	}
+
+	_, expiresIn, err = NewClient(Config{Host: "http://localhost", AccessToken: "static"}).FetchToken(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expiresIn != 0 {
+		t.Errorf("expected no expiry for a static token, got %d", expiresIn)
+	}
+}
+
+// TestClient_TokenSourceError verifies that token source failures and empty
+// tokens are returned as errors.
+func TestClient_TokenSourceError(t *testing.T) {
+	tests := []struct {
+		name string
+		src  TokenSource
+	}{
+		{"error", TokenSourceFunc(func() (*Token, error) { return nil, errors.New("no token") })},
+		{"empty token", TokenSourceFunc(func() (*Token, error) { return &Token{}, nil })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(Config{Host: "http://localhost", TokenSource: tt.src})
+			if _, err := c.getToken(context.Background()); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
 
-		resp := OAuthTokenResponse{
-			AccessToken: "test-token",
-			TokenType:   "Bearer",
-			ExpiresIn:   86400,
+// TestClient_StaticToken verifies that a configured access token is sent as
+// the bearer token without contacting Auth0.
+func TestClient_StaticToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer static-token" {
+			t.Errorf("expected Bearer static-token, got %s", got)
 		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
+		w.WriteHeader(http.StatusOK)
 	}))
 	defer server.Close()
 
-	c := &Client{
-		httpClient:   server.Client(),
-		clientID:     "test-client-id",
-		clientSecret: "test-client-secret",
-		auth0Domain:  server.URL[7:], // Remove "http://"
+	c := NewClient(Config{
+		Host:        server.URL,
+		AccessToken: "static-token",
+		Auth0Domain: "unreachable.invalid",
+	})
+
+	if _, err := c.doRequest(context.Background(), http.MethodGet, "/test", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
+}
 
-	// Override the token URL construction for testing
-	c.auth0Domain = server.URL[7:] // This won't work with https://
+// TestClient_TokenFile verifies that the access token file is read with
+// surrounding whitespace removed, and re-read after it changes.
+func TestClient_TokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("first-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
 
-	// For this test, we'll test the token caching behavior instead
-	c.accessToken = "cached-token"
-	c.tokenExpiry = time.Now().Add(time.Hour)
+	c := NewClient(Config{Host: "http://localhost", AccessTokenFile: tokenFile})
 
 	token, err := c.getToken(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if token != "cached-token" {
-		t.Errorf("expected cached-token, got %s", token)
+	if token != "first-token" {
+		t.Errorf("expected first-token, got %s", token)
 	}
-}
 
-// TestClient_TokenCaching verifies that getToken() returns the cached token
-// when it hasn't expired, avoiding unnecessary OAuth requests.
-func TestClient_TokenCaching(t *testing.T) {
-	c := &Client{
-		httpClient:   http.DefaultClient,
-		accessToken:  "cached-token",
-		tokenExpiry:  time.Now().Add(time.Hour), // Token still valid for 1 hour
-		auth0Domain:  "test.auth0.com",
-		clientID:     "test",
-		clientSecret: "test",
+	if err := os.WriteFile(tokenFile, []byte("rotated-token\n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
+	// Make sure the change is visible even on filesystems with coarse timestamps.
+	if err := os.Chtimes(tokenFile, time.Now(), time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("failed to update token file time: %v", err)
 	}
 
-	token, err := c.getToken(context.Background())
+	token, err = c.getToken(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if token != "cached-token" {
-		t.Errorf("expected cached-token, got %s", token)
+	if token != "rotated-token" {
+		t.Errorf("expected rotated-token, got %s", token)
 	}
 }
 
-// TestClient_TokenExpired verifies that getToken() fetches a new token when
-// the cached token has expired.
-// Note: This test is limited by the difficulty of mocking HTTPS URLs in tests.
-func TestClient_TokenExpired(t *testing.T) {
-	callCount := 0
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		callCount++
-		resp := OAuthTokenResponse{
-			AccessToken: "new-token",
-			TokenType:   "Bearer",
-			ExpiresIn:   86400,
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_ = json.NewEncoder(w).Encode(resp)
-	}))
-	defer server.Close()
+// TestClient_TokenFile_Errors verifies that a missing or empty token file is
+// reported as an error.
+func TestClient_TokenFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte(" \n"), 0o600); err != nil {
+		t.Fatalf("failed to write token file: %v", err)
+	}
 
-	c := &Client{
-		httpClient:   server.Client(),
-		accessToken:  "old-token",
-		tokenExpiry:  time.Now().Add(-time.Hour), // Expired
-		auth0Domain:  server.URL[7:],
-		clientID:     "test",
-		clientSecret: "test",
-	}
-
-	// This will fail because we can't easily mock https:// in the auth0 domain
-	// The test demonstrates the caching behavior works for non-expired tokens
-	_, err := c.getToken(context.Background())
-	if err == nil {
-		// If it somehow works, verify the token was refreshed
-		if callCount == 0 {
-			t.Error("expected token fetch to be called")
+	for _, path := range []string{filepath.Join(dir, "missing"), emptyFile} {
+		c := NewClient(Config{Host: "http://localhost", AccessTokenFile: path})
+		if _, err := c.getToken(context.Background()); err == nil {
+			t.Errorf("expected error for %s, got nil", path)
 		}
 	}
-	// Error is expected due to URL scheme mismatch in test
 }
 
 // TestClient_RetryOn5xx verifies that doRequest() retries on 5xx errors
@@ -368,12 +472,10 @@ func TestClient_RetryOn5xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		httpClient:   server.Client(),
-		host:         server.URL,
-		accessToken:  "test-token",
-		tokenExpiry:  time.Now().Add(time.Hour),
-		clientID:     "test",
-		clientSecret: "test",
+		httpClient:  server.Client(),
+		host:        server.URL,
+		accessToken: "test-token",
+		tokenExpiry: time.Now().Add(time.Hour),
 	}
 
 	resp, err := c.doRequest(context.Background(), http.MethodGet, "/test", nil)
@@ -402,12 +504,10 @@ func TestClient_NoRetryOn4xx(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		httpClient:   server.Client(),
-		host:         server.URL,
-		accessToken:  "test-token",
-		tokenExpiry:  time.Now().Add(time.Hour),
-		clientID:     "test",
-		clientSecret: "test",
+		httpClient:  server.Client(),
+		host:        server.URL,
+		accessToken: "test-token",
+		tokenExpiry: time.Now().Add(time.Hour),
 	}
 
 	resp, err := c.doRequest(context.Background(), http.MethodGet, "/test", nil)
@@ -424,7 +524,7 @@ func TestClient_NoRetryOn4xx(t *testing.T) {
 	}
 }
 
-// TestClient_MaxRetries verifies that doRequest() stops after maxRetries attempts
+// TestClient_MaxRetries verifies that doRequest() stops after the default policy's MaxAttempts
 // even if the server keeps returning 5xx errors. The final response with 500 status
 // is returned to the caller (not an error).
 func TestClient_MaxRetries(t *testing.T) {
@@ -437,12 +537,10 @@ func TestClient_MaxRetries(t *testing.T) {
 	defer server.Close()
 
 	c := &Client{
-		httpClient:   server.Client(),
-		host:         server.URL,
-		accessToken:  "test-token",
-		tokenExpiry:  time.Now().Add(time.Hour),
-		clientID:     "test",
-		clientSecret: "test",
+		httpClient:  server.Client(),
+		host:        server.URL,
+		accessToken: "test-token",
+		tokenExpiry: time.Now().Add(time.Hour),
 	}
 
 	resp, err := c.doRequest(context.Background(), http.MethodGet, "/test", nil)
@@ -451,7 +549,7 @@ func TestClient_MaxRetries(t *testing.T) {
 	}
 	defer resp.Body.Close()
 
-	expectedAttempts := maxRetries + 1 // maxRetries + initial attempt
+	expectedAttempts := DefaultRetryPolicy().MaxAttempts
 	if attemptCount != expectedAttempts {
 		t.Errorf("expected %d attempts, got %d", expectedAttempts, attemptCount)
 	}
@@ -462,8 +560,13 @@ func TestClient_MaxRetries(t *testing.T) {
 	}
 }
 
-// TestCalculateBackoff verifies the exponential backoff calculation.
-func TestCalculateBackoff(t *testing.T) {
+// TestRetryPolicy_Backoff verifies the exponential backoff calculation of the
+// default policy. The jittered delay lies between half of and the full
+// exponential delay.
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	maxDelay := policy.MaxDelay
+
 	tests := []struct {
 		attempt  int
 		expected time.Duration
@@ -474,44 +577,235 @@ func TestCalculateBackoff(t *testing.T) {
 		{3, 800 * time.Millisecond},  // 100ms * 2^3 = 800ms
 		{4, 1600 * time.Millisecond}, // 100ms * 2^4 = 1600ms
 		{5, 3200 * time.Millisecond}, // 100ms * 2^5 = 3200ms
-		{6, maxRetryDelay},           // 100ms * 2^6 = 6400ms, capped at 5000ms
-		{10, maxRetryDelay},          // Very high attempt, capped at max
+		{6, maxDelay},                // 100ms * 2^6 = 6400ms, capped at 5000ms
+		{10, maxDelay},               // Very high attempt, capped at max
+		{70, maxDelay},               // Shift overflow, capped at max
 	}
 
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("attempt_%d", tt.attempt), func(t *testing.T) {
-			got := calculateBackoff(tt.attempt)
-			if got != tt.expected {
-				t.Errorf("calculateBackoff(%d) = %v, expected %v", tt.attempt, got, tt.expected)
+			for i := 0; i < 100; i++ {
+				got := policy.backoff(tt.attempt)
+				if got < tt.expected/2 || got > tt.expected {
+					t.Fatalf("backoff(%d) = %v, expected between %v and %v", tt.attempt, got, tt.expected/2, tt.expected)
+				}
 			}
 		})
 	}
+
+	policy.Jitter = 0
+	if got := policy.backoff(1); got != 200*time.Millisecond {
+		t.Errorf("backoff(1) without jitter = %v, expected 200ms", got)
+	}
 }
 
-// TestClient_FetchToken_Error verifies error handling during token fetch.
-func TestClient_FetchToken_Error(t *testing.T) {
-	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusUnauthorized)
-		_, _ = w.Write([]byte(`{"error": "access_denied"}`))
-	}))
+// TestRetryPolicy_CanRetry verifies that non-idempotent methods are only
+// retried when the server rejected the request without processing it.
+func TestRetryPolicy_CanRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	tests := []struct {
+		method     string
+		statusCode int
+		expected   bool
+	}{
+		{http.MethodGet, http.StatusServiceUnavailable, true},
+		{http.MethodGet, http.StatusTooManyRequests, true},
+		{http.MethodGet, 0, true},
+		{http.MethodGet, http.StatusNotFound, false},
+		{http.MethodPut, http.StatusBadGateway, true},
+		{http.MethodDelete, 0, true},
+		{http.MethodPost, http.StatusTooManyRequests, true},
+		{http.MethodPost, http.StatusServiceUnavailable, false},
+		{http.MethodPost, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_%d", tt.method, tt.statusCode), func(t *testing.T) {
+			if got := policy.canRetry(tt.method, tt.statusCode); got != tt.expected {
+				t.Errorf("canRetry(%s, %d) = %v, expected %v", tt.method, tt.statusCode, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRetryAfter verifies parsing of the Retry-After header in both its
+// delay-seconds and HTTP-date forms.
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"missing", "", 0, false},
+		{"seconds", "120", 2 * time.Minute, true},
+		{"zero seconds", "0", 0, true},
+		{"negative seconds", "-1", 0, false},
+		{"http date", now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{"past http date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"invalid", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+
+			got, ok := retryAfter(header, now)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("retryAfter(%q) = %v, %v, expected %v, %v", tt.value, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+// TestClient_RetryOn429 verifies that doRequest() retries rate limited
+// requests, honouring Retry-After, and eventually succeeds.
+func TestClient_RetryOn429(t *testing.T) {
+	attemptCount := 0
+	server, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		if attemptCount < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error": "too many requests"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	})
 	defer server.Close()
 
-	c := &Client{
-		httpClient:   server.Client(),
-		clientID:     "test-client-id",
-		clientSecret: "test-client-secret",
-		// server.URL includes "https://", so we strip it to set the domain
-		auth0Domain: server.URL[8:],
+	resp, err := c.doRequest(context.Background(), http.MethodPost, "/test", map[string]string{"name": "test"})
+	if err != nil {
+		t.Fatalf("unexpected error after retries: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attemptCount != 3 {
+		t.Errorf("expected 3 attempts, got %d", attemptCount)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+// TestClient_RetryAfterExceedsBudget verifies that doRequest() does not wait
+// for a Retry-After longer than the retry budget, and returns the 429
+// response to the caller instead.
+func TestClient_RetryAfterExceedsBudget(t *testing.T) {
+	attemptCount := 0
+	server, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+	policy := DefaultRetryPolicy()
+	policy.MaxWait = time.Minute
+	c.retryPolicy = &policy
+
+	start := time.Now()
+	resp, err := c.doRequest(context.Background(), http.MethodGet, "/test", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attemptCount != 1 {
+		t.Errorf("expected 1 attempt, got %d", attemptCount)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", resp.StatusCode)
 	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected no wait, took %v", elapsed)
+	}
+}
 
-	_, _, err := c.FetchToken(context.Background())
-	if err == nil {
-		t.Fatal("expected error, got nil")
+// TestClient_MaxAttempts verifies that the policy's MaxAttempts is honoured
+// and that values below 1 disable retries.
+func TestClient_MaxAttempts(t *testing.T) {
+	tests := []struct {
+		maxAttempts      int
+		expectedAttempts int
+	}{
+		{2, 2},
+		{1, 1},
+		{0, 1},
 	}
 
-	// Verify the error message contains the status code but NOT the body (security)
-	expectedMsg := "failed to fetch token: status 401"
-	if err.Error() != expectedMsg {
-		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max_attempts_%d", tt.maxAttempts), func(t *testing.T) {
+			attemptCount := 0
+			server, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				attemptCount++
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			})
+			defer server.Close()
+			policy := DefaultRetryPolicy()
+			policy.MaxAttempts = tt.maxAttempts
+			c.retryPolicy = &policy
+
+			resp, err := c.doRequest(context.Background(), http.MethodGet, "/test", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if attemptCount != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, attemptCount)
+			}
+		})
+	}
+}
+
+// TestClient_NoRetryOfNonIdempotent5xx verifies that a POST failing with a
+// 5xx error is not retried, since the server may have applied it.
+func TestClient_NoRetryOfNonIdempotent5xx(t *testing.T) {
+	attemptCount := 0
+	server, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	resp, err := c.doRequest(context.Background(), http.MethodPost, "/test", map[string]string{"name": "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attemptCount != 1 {
+		t.Errorf("expected 1 attempt, got %d", attemptCount)
+	}
+}
+
+// TestClient_RetryHonoursContext verifies that cancelling the context stops
+// doRequest() while it waits between retries, instead of sleeping out the
+// full Retry-After delay.
+func TestClient_RetryHonoursContext(t *testing.T) {
+	server, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.doRequest(ctx, http.MethodGet, "/test", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context deadline error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected doRequest to return promptly, took %v", elapsed)
 	}
 }
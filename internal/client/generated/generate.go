@@ -0,0 +1,13 @@
+// Package generated contains the Grepr API types generated by oapi-codegen
+// from the OpenAPI spec vendored in openapi.json.
+//
+// Both openapi.json and the generated models.gen.go are committed, so builds
+// do not depend on the spec currently published at
+// https://docs.grepr.ai/openapi.json. To pick up an API change, run:
+//
+//	make update-spec generate
+//
+// and commit both files.
+package generated
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen -config oapi-codegen.yaml openapi.json
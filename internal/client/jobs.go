@@ -2,21 +2,117 @@ package client
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
 var pollInterval = 5 * time.Second
 
+// IdempotencyKeyHeader is the request header carrying the idempotency key of
+// a create request, so that the server can recognise a retried request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyKeyTag is the tag CreateAsyncJob stamps on the job it creates,
+// with the request's idempotency key as its value. It proves that a job found
+// by name after an ambiguous failure was created by that request, since the
+// API does not echo the idempotency key back. The tag stays on the job, so
+// callers that expose a job's tags should leave it out.
+const IdempotencyKeyTag = "grepr_idempotency_key"
+
 // CreateAsyncJob creates a new async streaming job (pipeline).
 //
 // The job is created in CREATED state and will automatically transition through
 // PENDING -> STARTING -> RUNNING (or STOPPED if desired_state is STOPPED).
 // Use WaitForState or WaitForStableState to wait for the job to be ready.
+//
+// Every attempt carries the same idempotency key, both as a header and as the
+// IdempotencyKeyTag tag. If an attempt fails ambiguously (a network error or a
+// 5xx response, after which the job may or may not exist), the job is looked
+// up by name before the create is retried, so that a lost response neither
+// leaves an untracked job behind nor creates a duplicate. A job found by name
+// is only returned if it carries this request's key; any other job with the
+// name results in a *CreateConflictError. Retries wait according to the
+// client's RetryPolicy, within its MaxWait budget.
 func (c *Client) CreateAsyncJob(ctx context.Context, req CreateJobRequest) (*Job, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, EndpointJobsAsync, req)
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	header.Set(IdempotencyKeyHeader, key)
+
+	tags := make(map[string]string)
+	if req.Tags != nil {
+		for k, v := range *req.Tags {
+			tags[k] = v
+		}
+	}
+	tags[IdempotencyKeyTag] = key
+	req.Tags = &tags
+
+	policy := c.retryPolicyOrDefault()
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		job, err := c.createAsyncJob(ctx, req, header)
+		if err == nil {
+			return job, nil
+		}
+		if !isAmbiguousError(ctx, err) {
+			return nil, err
+		}
+
+		// The job may have been created even though the request failed.
+		existing, lookupErr := c.GetJobByName(ctx, req.Name)
+		if lookupErr != nil {
+			return nil, fmt.Errorf("%w (checking whether the job was created also failed: %v)", err, lookupErr)
+		}
+		if existing != nil {
+			if existing.Tags[IdempotencyKeyTag] != key {
+				return nil, &CreateConflictError{Name: req.Name, ID: existing.Id, Err: err}
+			}
+			return existing, nil
+		}
+
+		delay := policy.backoff(attempt)
+		if attempt+1 >= policy.MaxAttempts || (policy.MaxWait > 0 && waited+delay > policy.MaxWait) {
+			return nil, err
+		}
+		waited += delay
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// CreateConflictError is returned by CreateAsyncJob when a create request
+// failed ambiguously and a job with the requested name exists that was not
+// created by that request, e.g. one created concurrently by another client.
+type CreateConflictError struct {
+	Name string
+	ID   string
+
+	// Err is the error of the failed create request.
+	Err error
+}
+
+func (e *CreateConflictError) Error() string {
+	return fmt.Sprintf("creating job %q failed (%v) and a job with that name that was not created by this request exists (ID: %s)", e.Name, e.Err, e.ID)
+}
+
+func (e *CreateConflictError) Unwrap() error {
+	return e.Err
+}
+
+// createAsyncJob sends a single create request.
+func (c *Client) createAsyncJob(ctx context.Context, req CreateJobRequest, header http.Header) (*Job, error) {
+	resp, err := c.doRequestWithHeader(ctx, http.MethodPost, EndpointJobsAsync, req, header)
 	if err != nil {
 		return nil, err
 	}
@@ -29,6 +125,30 @@ func (c *Client) CreateAsyncJob(ctx context.Context, req CreateJobRequest) (*Job
 	return &job, nil
 }
 
+// isAmbiguousError returns true if err leaves it unknown whether the server
+// applied the request: a network error or a 5xx response. Errors caused by
+// cancelling ctx are not ambiguous, as there is no time left to resolve them.
+func isAmbiguousError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.IsServerError()
+}
+
+// newIdempotencyKey returns a random idempotency key.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // GetJob retrieves a job by ID.
 func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
 	path := fmt.Sprintf(EndpointJob, url.PathEscape(id))
@@ -46,12 +166,157 @@ func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
 	return &job, nil
 }
 
-// GetJobByName retrieves a job by name.
+// GetJobByName retrieves a live job by its exact name.
+//
+// The list endpoint's name filter is not relied on for exact matching: every
+// page of results is checked for an exact name match, and jobs in a terminal
+// state (e.g., DELETED) are skipped.
 //
 // Returns nil (not an error) if no job with the given name exists.
+// Returns an *AmbiguousNameError if more than one live job has the name.
 // This is used for adoption - checking if a pipeline with a given name already exists.
 func (c *Client) GetJobByName(ctx context.Context, name string) (*Job, error) {
-	path := fmt.Sprintf("%s?name=%s", EndpointJobs, url.QueryEscape(name))
+	var matches []Job
+	for job, err := range c.Jobs(ctx, ListJobsOptions{Name: name}) {
+		if err != nil {
+			return nil, err
+		}
+		if job.Name != name || IsTerminal(job.State) {
+			continue
+		}
+		matches = append(matches, job)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, 0, len(matches))
+		for _, job := range matches {
+			ids = append(ids, job.Id)
+		}
+		return nil, &AmbiguousNameError{Name: name, IDs: ids}
+	}
+}
+
+// AmbiguousNameError is returned by GetJobByName when more than one live job
+// has the requested name, so the caller cannot safely pick one.
+type AmbiguousNameError struct {
+	Name string
+	IDs  []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("found %d pipelines named %q (IDs: %s)", len(e.IDs), e.Name, strings.Join(e.IDs, ", "))
+}
+
+// ListJobsOptions filters the jobs returned by Jobs and ListJobs.
+//
+// Name is sent to the API as the name query parameter. State and Tags are
+// applied by the client to each page, since the list endpoint does not filter
+// on them. Zero values match every job.
+type ListJobsOptions struct {
+	// Name filters jobs by name, using the API's name matching.
+	Name string
+
+	// State, if set, only returns jobs in this state.
+	State JobState
+
+	// Tags, if set, only returns jobs that have all of these tags with matching values.
+	Tags map[string]string
+}
+
+// matches returns true if the job satisfies the client-side filters.
+func (o ListJobsOptions) matches(job *Job) bool {
+	if o.State != "" && job.State != o.State {
+		return false
+	}
+	for k, v := range o.Tags {
+		if got, ok := job.Tags[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Jobs returns an iterator over all jobs matching opts.
+//
+// Pages are fetched lazily as the iterator advances, following the page token
+// of each JobsResponse until the last page. If a request fails, the error is
+// yielded once and iteration stops. Breaking out of the loop early stops any
+// further requests.
+//
+//	for job, err := range c.Jobs(ctx, client.ListJobsOptions{State: client.JobStateRunning}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(job.Name)
+//	}
+func (c *Client) Jobs(ctx context.Context, opts ListJobsOptions) iter.Seq2[Job, error] {
+	return func(yield func(Job, error) bool) {
+		pageToken := ""
+
+		for {
+			jobsResp, err := c.listJobsPage(ctx, opts.Name, pageToken)
+			if err != nil {
+				yield(Job{}, err)
+				return
+			}
+
+			if jobsResp.Items != nil {
+				for _, job := range *jobsResp.Items {
+					if !opts.matches(&job) {
+						continue
+					}
+					if !yield(job, nil) {
+						return
+					}
+				}
+			}
+
+			if jobsResp.NextPageToken == nil || *jobsResp.NextPageToken == "" {
+				return
+			}
+
+			// Guard against a server that keeps returning the same page
+			if *jobsResp.NextPageToken == pageToken {
+				yield(Job{}, fmt.Errorf("pagination did not advance past page token %q", pageToken))
+				return
+			}
+			pageToken = *jobsResp.NextPageToken
+		}
+	}
+}
+
+// ListJobs retrieves all jobs matching opts, following pagination until every
+// page has been read.
+func (c *Client) ListJobs(ctx context.Context, opts ListJobsOptions) ([]Job, error) {
+	var jobs []Job
+	for job, err := range c.Jobs(ctx, opts) {
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// listJobsPage fetches a single page of the list jobs endpoint.
+func (c *Client) listJobsPage(ctx context.Context, name, pageToken string) (*JobsResponse, error) {
+	query := url.Values{}
+	if name != "" {
+		query.Set("name", name)
+	}
+	if pageToken != "" {
+		query.Set("pageToken", pageToken)
+	}
+
+	path := EndpointJobs
+	if len(query) > 0 {
+		path = fmt.Sprintf("%s?%s", EndpointJobs, query.Encode())
+	}
 
 	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -63,11 +328,7 @@ func (c *Client) GetJobByName(ctx context.Context, name string) (*Job, error) {
 		return nil, err
 	}
 
-	if jobsResp.Items == nil || len(*jobsResp.Items) == 0 {
-		return nil, nil
-	}
-
-	return &(*jobsResp.Items)[0], nil
+	return &jobsResp, nil
 }
 
 // UpdateJob updates an existing job.
@@ -6,6 +6,8 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -167,6 +169,236 @@ func TestClient_GetJobByName_NotFound(t *testing.T) {
 	}
 }
 
+// TestClient_GetJobByName_ExactMatch verifies that GetJobByName() ignores jobs
+// whose name only partially matches and jobs in a terminal state.
+func TestClient_GetJobByName_ExactMatch(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		items := []Job{
+			{Id: "prefix-match", Name: "my_pipeline_v2", State: JobStateRunning},
+			{Id: "deleted", Name: "my_pipeline", State: JobStateDeleted},
+			{Id: "live", Name: "my_pipeline", State: JobStateRunning},
+		}
+		resp := JobsResponse{Items: &items}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	job, err := client.GetJobByName(context.Background(), "my_pipeline")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job == nil || job.Id != "live" {
+		t.Errorf("expected job live, got %+v", job)
+	}
+}
+
+// TestClient_GetJobByName_OnlyTerminal verifies that GetJobByName() returns nil
+// when the only jobs with the name are in a terminal state.
+func TestClient_GetJobByName_OnlyTerminal(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		items := []Job{{Id: "deleted", Name: "my_pipeline", State: JobStateDeleted}}
+		resp := JobsResponse{Items: &items}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	job, err := client.GetJobByName(context.Background(), "my_pipeline")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job != nil {
+		t.Errorf("expected nil job, got %+v", job)
+	}
+}
+
+// TestClient_GetJobByName_Ambiguous verifies that GetJobByName() returns an
+// AmbiguousNameError listing every match when several live jobs share a name.
+func TestClient_GetJobByName_Ambiguous(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		items := []Job{
+			{Id: "job-1", Name: "my_pipeline", State: JobStateRunning},
+			{Id: "job-2", Name: "my_pipeline", State: JobStateStopped},
+		}
+		resp := JobsResponse{Items: &items}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	_, err := client.GetJobByName(context.Background(), "my_pipeline")
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	ambiguousErr, ok := err.(*AmbiguousNameError)
+	if !ok {
+		t.Fatalf("expected *AmbiguousNameError, got %T", err)
+	}
+	if len(ambiguousErr.IDs) != 2 || ambiguousErr.IDs[0] != "job-1" || ambiguousErr.IDs[1] != "job-2" {
+		t.Errorf("expected IDs [job-1 job-2], got %v", ambiguousErr.IDs)
+	}
+}
+
+// TestClient_ListJobs verifies that ListJobs() follows the page token across
+// multiple pages and returns the jobs from every page.
+func TestClient_ListJobs(t *testing.T) {
+	pages := map[string]JobsResponse{
+		"":       {Items: &[]Job{{Id: "job-1"}, {Id: "job-2"}}, NextPageToken: stringPtr("page-2")},
+		"page-2": {Items: &[]Job{{Id: "job-3"}}, NextPageToken: stringPtr("page-3")},
+		"page-3": {Items: &[]Job{{Id: "job-4"}}},
+	}
+
+	requests := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/v1/jobs" {
+			t.Errorf("expected /api/v1/jobs, got %s", r.URL.Path)
+		}
+
+		page, ok := pages[r.URL.Query().Get("pageToken")]
+		if !ok {
+			t.Fatalf("unexpected page token %q", r.URL.Query().Get("pageToken"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	defer server.Close()
+
+	jobs, err := client.ListJobs(context.Background(), ListJobsOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+	if len(jobs) != 4 {
+		t.Fatalf("expected 4 jobs, got %d", len(jobs))
+	}
+	for i, id := range []string{"job-1", "job-2", "job-3", "job-4"} {
+		if jobs[i].Id != id {
+			t.Errorf("expected job %d to be %s, got %s", i, id, jobs[i].Id)
+		}
+	}
+}
+
+// TestClient_ListJobs_StuckPagination verifies that ListJobs() fails instead of
+// looping forever when the server returns the same page token again.
+func TestClient_ListJobs_StuckPagination(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		resp := JobsResponse{Items: &[]Job{{Id: "job-1"}}, NextPageToken: stringPtr("same")}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	_, err := client.ListJobs(context.Background(), ListJobsOptions{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+// TestClient_ListJobs_Filters verifies that ListJobs() sends the name filter
+// to the API and applies the state and tag filters to every page.
+func TestClient_ListJobs_Filters(t *testing.T) {
+	pages := map[string]JobsResponse{
+		"": {
+			Items: &[]Job{
+				{Id: "job-1", State: JobStateRunning, Tags: map[string]string{"env": "prod"}},
+				{Id: "job-2", State: JobStateStopped, Tags: map[string]string{"env": "prod"}},
+			},
+			NextPageToken: stringPtr("page-2"),
+		},
+		"page-2": {
+			Items: &[]Job{
+				{Id: "job-3", State: JobStateRunning, Tags: map[string]string{"env": "dev"}},
+				{Id: "job-4", State: JobStateRunning, Tags: map[string]string{"env": "prod", "team": "a"}},
+			},
+		},
+	}
+
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "ingest" {
+			t.Errorf("expected name=ingest, got %s", r.URL.Query().Get("name"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("pageToken")])
+	})
+	defer server.Close()
+
+	jobs, err := client.ListJobs(context.Background(), ListJobsOptions{
+		Name:  "ingest",
+		State: JobStateRunning,
+		Tags:  map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(jobs) != 2 || jobs[0].Id != "job-1" || jobs[1].Id != "job-4" {
+		t.Errorf("expected jobs job-1 and job-4, got %+v", jobs)
+	}
+}
+
+// TestClient_Jobs_EarlyBreak verifies that breaking out of the Jobs() iterator
+// stops fetching further pages.
+func TestClient_Jobs_EarlyBreak(t *testing.T) {
+	requests := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		resp := JobsResponse{
+			Items:         &[]Job{{Id: "job-" + r.URL.Query().Get("pageToken")}},
+			NextPageToken: stringPtr(fmt.Sprintf("%d", requests)),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+	defer server.Close()
+
+	count := 0
+	for _, err := range client.Jobs(context.Background(), ListJobsOptions{}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+// TestClient_Jobs_Error verifies that the Jobs() iterator yields request
+// errors and stops iterating.
+func TestClient_Jobs_Error(t *testing.T) {
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": "forbidden"}`))
+	})
+	defer server.Close()
+
+	errs := 0
+	for _, err := range client.Jobs(context.Background(), ListJobsOptions{}) {
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		apiErr, ok := err.(*APIError)
+		if !ok || !apiErr.IsForbidden() {
+			t.Errorf("expected forbidden APIError, got %v", err)
+		}
+		errs++
+	}
+
+	if errs != 1 {
+		t.Errorf("expected exactly 1 error, got %d", errs)
+	}
+}
+
 // TestClient_CreateAsyncJob verifies that CreateAsyncJob() sends a properly
 // formatted POST request to create a new async pipeline.
 func TestClient_CreateAsyncJob(t *testing.T) {
@@ -200,6 +432,9 @@ func TestClient_CreateAsyncJob(t *testing.T) {
 		if req.Processing != generated.CreateJobProcessingSTREAMING {
 			t.Errorf("expected processing STREAMING, got %s", req.Processing)
 		}
+		if req.Tags == nil || (*req.Tags)["env"] != "prod" || (*req.Tags)[IdempotencyKeyTag] != r.Header.Get(IdempotencyKeyHeader) {
+			t.Errorf("expected the env tag and the idempotency key tag, got %v", req.Tags)
+		}
 
 		w.WriteHeader(http.StatusCreated)
 		w.Header().Set("Content-Type", "application/json")
@@ -207,6 +442,7 @@ func TestClient_CreateAsyncJob(t *testing.T) {
 	})
 	defer server.Close()
 
+	tags := map[string]string{"env": "prod"}
 	req := CreateJobRequest{
 		Name:       "new_pipeline",
 		Execution:  generated.CreateJobExecutionASYNCHRONOUS,
@@ -215,6 +451,7 @@ func TestClient_CreateAsyncJob(t *testing.T) {
 			Vertices: []generated.Operation{},
 			Edges:    []string{},
 		},
+		Tags: &tags,
 	}
 
 	job, err := client.CreateAsyncJob(context.Background(), req)
@@ -228,6 +465,190 @@ func TestClient_CreateAsyncJob(t *testing.T) {
 	if job.State != JobStatePending {
 		t.Errorf("expected State PENDING, got %s", job.State)
 	}
+	if _, ok := tags[IdempotencyKeyTag]; ok {
+		t.Error("CreateAsyncJob() must not modify the request's tags")
+	}
+}
+
+// TestClient_CreateAsyncJob_AmbiguousFailure verifies that when a create
+// fails with a 5xx error after the job was created, CreateAsyncJob() finds the
+// job by name and its idempotency key tag instead of creating it again.
+func TestClient_CreateAsyncJob_AmbiguousFailure(t *testing.T) {
+	var created *Job
+
+	posts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			posts++
+			var req CreateJobRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			if r.Header.Get(IdempotencyKeyHeader) == "" {
+				t.Errorf("expected an %s header", IdempotencyKeyHeader)
+			}
+			// The job is created, but the response reports a gateway error.
+			created = &Job{Id: "new-job-id", Name: req.Name, State: JobStatePending, Tags: *req.Tags}
+			w.WriteHeader(http.StatusBadGateway)
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(JobsResponse{Items: &[]Job{*created}})
+		}
+	})
+	defer server.Close()
+
+	job, err := client.CreateAsyncJob(context.Background(), CreateJobRequest{Name: "new_pipeline"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Id != "new-job-id" {
+		t.Errorf("expected Id new-job-id, got %s", job.Id)
+	}
+	if posts != 1 {
+		t.Errorf("expected 1 create request, got %d", posts)
+	}
+}
+
+// TestClient_CreateAsyncJob_AmbiguousFailureForeignJob verifies that when a
+// create fails ambiguously and the lookup finds a job with the same name that
+// does not carry the request's idempotency key, CreateAsyncJob() reports a
+// conflict instead of returning that job.
+func TestClient_CreateAsyncJob_AmbiguousFailureForeignJob(t *testing.T) {
+	tests := []struct {
+		name string
+		tags map[string]string
+	}{
+		{"untagged", map[string]string{"env": "prod"}},
+		{"other idempotency key", map[string]string{IdempotencyKeyTag: "other-key"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			foreign := Job{Id: "foreign-job-id", Name: "new_pipeline", State: JobStateRunning, Tags: tt.tags}
+
+			posts := 0
+			server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.Method {
+				case http.MethodPost:
+					posts++
+					w.WriteHeader(http.StatusBadGateway)
+				case http.MethodGet:
+					_ = json.NewEncoder(w).Encode(JobsResponse{Items: &[]Job{foreign}})
+				}
+			})
+			defer server.Close()
+
+			job, err := client.CreateAsyncJob(context.Background(), CreateJobRequest{Name: "new_pipeline"})
+			if job != nil {
+				t.Errorf("expected no job, got %s", job.Id)
+			}
+			var conflictErr *CreateConflictError
+			if !errors.As(err, &conflictErr) {
+				t.Fatalf("expected a CreateConflictError, got %v", err)
+			}
+			if conflictErr.ID != foreign.Id || conflictErr.Name != "new_pipeline" {
+				t.Errorf("expected a conflict with %s, got %+v", foreign.Id, conflictErr)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+				t.Errorf("expected the create's 502 error to be wrapped, got %v", err)
+			}
+			if posts != 1 {
+				t.Errorf("expected 1 create request, got %d", posts)
+			}
+		})
+	}
+}
+
+// TestClient_CreateAsyncJob_RetryBudget verifies that CreateAsyncJob() does
+// not wait beyond the retry policy's MaxWait before retrying a create.
+func TestClient_CreateAsyncJob_RetryBudget(t *testing.T) {
+	posts := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			posts++
+			w.WriteHeader(http.StatusBadGateway)
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(JobsResponse{Items: &[]Job{}})
+		}
+	})
+	defer server.Close()
+	client.retryPolicy = &RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour, MaxWait: time.Second}
+
+	start := time.Now()
+	_, err := client.CreateAsyncJob(context.Background(), CreateJobRequest{Name: "new_pipeline"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !apiErr.IsServerError() {
+		t.Fatalf("expected a 5xx API error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected CreateAsyncJob() to give up within the budget, took %v", elapsed)
+	}
+	if posts != 1 {
+		t.Errorf("expected 1 create request, got %d", posts)
+	}
+}
+
+// TestClient_CreateAsyncJob_RetryAfterNetworkError verifies that when a create
+// fails with a network error and the job does not exist, CreateAsyncJob()
+// retries it with the same idempotency key.
+func TestClient_CreateAsyncJob_RetryAfterNetworkError(t *testing.T) {
+	var keys []string
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+			if len(keys) == 1 {
+				// Drop the connection without a response.
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Fatalf("failed to hijack connection: %v", err)
+				}
+				conn.Close()
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(Job{Id: "new-job-id", Name: "new_pipeline"})
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(JobsResponse{Items: &[]Job{}})
+		}
+	})
+	defer server.Close()
+
+	job, err := client.CreateAsyncJob(context.Background(), CreateJobRequest{Name: "new_pipeline"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Id != "new-job-id" {
+		t.Errorf("expected Id new-job-id, got %s", job.Id)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("expected 2 create requests with the same idempotency key, got %q", keys)
+	}
+}
+
+// TestClient_CreateAsyncJob_NoRetryOn4xx verifies that a rejected create is
+// neither looked up nor retried.
+func TestClient_CreateAsyncJob_NoRetryOn4xx(t *testing.T) {
+	requests := 0
+	server, client := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	defer server.Close()
+
+	_, err := client.CreateAsyncJob(context.Background(), CreateJobRequest{Name: "new_pipeline"})
+	if apiErr, ok := err.(*APIError); !ok || !apiErr.IsBadRequest() {
+		t.Fatalf("expected a 400 API error, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
 }
 
 // TestClient_UpdateJob verifies that UpdateJob() sends a properly formatted
@@ -431,3 +852,8 @@ func TestClient_WaitForState_Success(t *testing.T) {
 		t.Errorf("expected state RUNNING, got %s", job.State)
 	}
 }
+
+// stringPtr returns a pointer to the given string.
+func stringPtr(s string) *string {
+	return &s
+}
@@ -29,6 +29,9 @@ type (
 	// JobGraph defines the pipeline's data flow: sources, operations, and sinks.
 	JobGraph = generated.GreprJobGraph
 
+	// Operation is a single vertex of a JobGraph, discriminated by its type.
+	Operation = generated.Operation
+
 	// JobsResponse is the paginated response from the list jobs endpoint.
 	JobsResponse = generated.ItemsCollectionReadJob
 )
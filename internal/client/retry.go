@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed API requests.
+//
+// A request is retried on network errors and on responses whose status is in
+// RetryableStatus, with exponential backoff between attempts. A Retry-After
+// header on the response replaces the backoff delay. Retrying stops once
+// MaxAttempts requests have been made, or when the next wait would exceed
+// MaxWait; the last response is then returned to the caller.
+//
+// Requests whose method is not in IdempotentMethods (such as POST) may have
+// been applied by the server even though they failed, so they are only
+// retried on 429 Too Many Requests, which the server returns without
+// processing the request.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values below 1 are treated as 1 (no retries).
+	MaxAttempts int
+
+	// BaseDelay is the backoff delay before the first retry. It doubles with
+	// each further retry, up to MaxDelay.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff delay of a single retry.
+	MaxDelay time.Duration
+
+	// MaxWait is the maximum total time a request may spend waiting between
+	// retries, including waits requested by Retry-After. Zero means no limit.
+	MaxWait time.Duration
+
+	// Jitter is the fraction of each backoff delay, between 0 and 1, that is
+	// randomized, so that concurrent clients spread out their retries.
+	Jitter float64
+
+	// RetryableStatus is the set of HTTP status codes that are retried.
+	RetryableStatus map[int]bool
+
+	// IdempotentMethods is the set of HTTP methods that are safe to retry
+	// after any retryable failure.
+	IdempotentMethods map[string]bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when Config.RetryPolicy is nil:
+// 4 attempts with a backoff from 100ms up to 5s, jitter of half the delay, and a
+// total wait of at most 2 minutes. 429 and 5xx gateway/availability errors are
+// retried; GET, HEAD, OPTIONS, PUT and DELETE are treated as idempotent.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		MaxWait:     2 * time.Minute,
+		Jitter:      0.5,
+		RetryableStatus: map[int]bool{
+			http.StatusTooManyRequests:     true,
+			http.StatusInternalServerError: true,
+			http.StatusBadGateway:          true,
+			http.StatusServiceUnavailable:  true,
+			http.StatusGatewayTimeout:      true,
+		},
+		IdempotentMethods: map[string]bool{
+			http.MethodGet:     true,
+			http.MethodHead:    true,
+			http.MethodOptions: true,
+			http.MethodPut:     true,
+			http.MethodDelete:  true,
+		},
+	}
+}
+
+// canRetry returns true if a request with the given method that failed with
+// statusCode may be retried. A statusCode of 0 denotes a network error.
+func (p RetryPolicy) canRetry(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return p.RetryableStatus[statusCode]
+	}
+	if !p.IdempotentMethods[method] {
+		return false
+	}
+	return statusCode == 0 || p.RetryableStatus[statusCode]
+}
+
+// backoff calculates the retry delay using exponential backoff with jitter.
+// The delay is min(BaseDelay * 2^attempt, MaxDelay), of which a random
+// fraction of up to Jitter is subtracted.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay * time.Duration(1<<uint(attempt))
+	if delay > p.MaxDelay || delay <= 0 {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		jitter := time.Duration(float64(delay) * min(p.Jitter, 1))
+		delay -= rand.N(jitter + 1)
+	}
+	return delay
+}
+
+// retryAfter parses the Retry-After header, given either as a number of
+// seconds or as an HTTP date, into a delay relative to now. It returns false
+// if the header is missing or invalid. Dates in the past yield a zero delay.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// sleepContext waits for d, returning early with the context's error if ctx
+// is cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
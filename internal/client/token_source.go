@@ -0,0 +1,287 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultCommandTokenLifetime is how long a token printed by a token command
+// is used when the command does not report an expiry.
+const defaultCommandTokenLifetime = 5 * time.Minute
+
+// Token is an access token for the Grepr API. It carries the fields of
+// golang.org/x/oauth2.Token that the client uses.
+type Token struct {
+	// AccessToken is sent as the bearer token of API requests.
+	AccessToken string
+
+	// Expiry is when the token expires. The zero value means the token has no
+	// known expiry; the client then asks its TokenSource for a token before
+	// every request instead of caching it.
+	Expiry time.Time
+}
+
+// TokenSource supplies the access tokens the client authenticates with. It
+// mirrors golang.org/x/oauth2.TokenSource but returns a *Token; use
+// FromOAuth2 to authenticate with an oauth2 source.
+//
+// The client caches tokens until shortly before their expiry, so sources do
+// not need to cache tokens themselves. Implementations must be safe for
+// concurrent use.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// ContextTokenSource is a TokenSource that can fetch a token on behalf of a
+// request, giving up when the request's context is cancelled. The client
+// prefers TokenContext over Token when a source implements it.
+type ContextTokenSource interface {
+	TokenSource
+	TokenContext(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts an ordinary function to a TokenSource.
+type TokenSourceFunc func() (*Token, error)
+
+// Token calls f.
+func (f TokenSourceFunc) Token() (*Token, error) {
+	return f()
+}
+
+// FromOAuth2 adapts a golang.org/x/oauth2.TokenSource to a TokenSource. Only
+// the access token and expiry of its tokens are used.
+func FromOAuth2(src oauth2.TokenSource) TokenSource {
+	return TokenSourceFunc(func() (*Token, error) {
+		t, err := src.Token()
+		if err != nil {
+			return nil, err
+		}
+		return &Token{AccessToken: t.AccessToken, Expiry: t.Expiry}, nil
+	})
+}
+
+// fetchToken gets a token from src, passing ctx along if src supports it.
+func fetchToken(ctx context.Context, src TokenSource) (*Token, error) {
+	if cs, ok := src.(ContextTokenSource); ok {
+		return cs.TokenContext(ctx)
+	}
+	return src.Token()
+}
+
+// auth0TokenSource obtains tokens from Auth0 with the OAuth2 client
+// credentials flow.
+type auth0TokenSource struct {
+	httpClient   *http.Client
+	domain       string
+	clientID     string
+	clientSecret string
+}
+
+// NewAuth0TokenSource returns a TokenSource that obtains tokens from the Auth0
+// tenant at domain using the client credentials flow. An empty domain uses
+// the production Grepr tenant, and a nil httpClient uses http.DefaultClient.
+func NewAuth0TokenSource(httpClient *http.Client, domain, clientID, clientSecret string) TokenSource {
+	if domain == "" {
+		domain = defaultAuth0Domain
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &auth0TokenSource{
+		httpClient:   httpClient,
+		domain:       domain,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	}
+}
+
+// Token fetches a new token from Auth0.
+func (s *auth0TokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+// TokenContext fetches a new token from Auth0.
+func (s *auth0TokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	tokenURL := fmt.Sprintf("https://%s/oauth/token", s.domain)
+
+	reqBody := OAuthTokenRequest{
+		ClientID:     s.clientID,
+		ClientSecret: s.clientSecret,
+		Audience:     "service",
+		GrantType:    "client_credentials",
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch token: status %d", resp.StatusCode)
+	}
+
+	var tokenResp OAuthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	return &Token{
+		AccessToken: tokenResp.AccessToken,
+		Expiry:      time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// staticTokenSource always returns the same token.
+type staticTokenSource struct {
+	token string
+}
+
+// NewStaticTokenSource returns a TokenSource that always returns token. The
+// token is never refreshed, so it must stay valid for as long as the client
+// is used.
+func NewStaticTokenSource(token string) TokenSource {
+	return &staticTokenSource{token: token}
+}
+
+// Token returns the static token.
+func (s *staticTokenSource) Token() (*Token, error) {
+	return &Token{AccessToken: s.token}, nil
+}
+
+// fileTokenSource reads the token from a file, re-reading it when it changes.
+type fileTokenSource struct {
+	path string
+
+	// Cached file state, protected by mu.
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	token   string
+}
+
+// NewFileTokenSource returns a TokenSource that reads the token from the file
+// at path, ignoring surrounding whitespace such as a trailing newline. The
+// file is re-read whenever its modification time or size changes, so that a
+// token rotated by another process is picked up.
+func NewFileTokenSource(path string) TokenSource {
+	return &fileTokenSource{path: path}
+}
+
+// Token returns the token stored in the file. The returned token has no
+// expiry, so the client consults the file before every request; the file is
+// only read again when it has changed since the last read.
+func (s *fileTokenSource) Token() (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read access token file: %w", err)
+	}
+	if s.token != "" && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return &Token{AccessToken: s.token}, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read access token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return nil, fmt.Errorf("access token file %s is empty", s.path)
+	}
+
+	s.token = token
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	return &Token{AccessToken: token}, nil
+}
+
+// commandTokenSource obtains tokens by running an external command.
+type commandTokenSource struct {
+	name string
+	args []string
+}
+
+// NewCommandTokenSource returns a TokenSource that runs the command name with
+// args to obtain a token, e.g. a cloud CLI or a secrets manager helper.
+//
+// The command must print either the bare token, which is then used for
+// defaultCommandTokenLifetime, or a JSON object of the same form as an OAuth
+// token response, such as {"access_token": "...", "expires_in": 3600}.
+func NewCommandTokenSource(name string, args ...string) TokenSource {
+	return &commandTokenSource{name: name, args: args}
+}
+
+// Token runs the command to obtain a new token.
+func (s *commandTokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+// TokenContext runs the command to obtain a new token. The command is killed
+// if ctx is cancelled.
+func (s *commandTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	cmd := exec.CommandContext(ctx, s.name, s.args...)
+	out, err := cmd.Output()
+	if err != nil {
+		// The command's output is not included as it may contain credentials.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("access token command %s failed: exit status %d", s.name, exitErr.ExitCode())
+		}
+		return nil, fmt.Errorf("failed to run access token command %s: %w", s.name, err)
+	}
+
+	output := strings.TrimSpace(string(out))
+	if output == "" {
+		return nil, fmt.Errorf("access token command %s printed no token", s.name)
+	}
+
+	if !strings.HasPrefix(output, "{") {
+		return &Token{
+			AccessToken: output,
+			Expiry:      time.Now().Add(defaultCommandTokenLifetime),
+		}, nil
+	}
+
+	var tokenResp OAuthTokenResponse
+	if err := json.Unmarshal([]byte(output), &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to decode output of access token command %s: %w", s.name, err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("access token command %s printed no access_token", s.name)
+	}
+
+	lifetime := defaultCommandTokenLifetime
+	if tokenResp.ExpiresIn > 0 {
+		lifetime = time.Duration(tokenResp.ExpiresIn) * time.Second
+	}
+	return &Token{
+		AccessToken: tokenResp.AccessToken,
+		Expiry:      time.Now().Add(lifetime),
+	}, nil
+}
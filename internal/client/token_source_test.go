@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TestAuth0TokenSource verifies that the Auth0 token source sends a client
+// credentials request to the token endpoint and sets the token's expiry from
+// the response.
+func TestAuth0TokenSource(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/oauth/token" {
+			t.Errorf("expected /oauth/token, got %s", r.URL.Path)
+		}
+
+		var req OAuthTokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		if req.ClientID != "test-client-id" {
+			t.Errorf("expected client_id test-client-id, got %s", req.ClientID)
+		}
+		if req.ClientSecret != "test-client-secret" {
+			t.Errorf("expected client_secret test-client-secret, got %s", req.ClientSecret)
+		}
+		if req.Audience != "service" {
+			t.Errorf("expected audience service, got %s", req.Audience)
+		}
+		if req.GrantType != "client_credentials" {
+			t.Errorf("expected grant_type client_credentials, got %s", req.GrantType)
+		}
+
+		resp := OAuthTokenResponse{
+			AccessToken: "test-token",
+			TokenType:   "Bearer",
+			ExpiresIn:   86400,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	// server.URL includes "https://", so we strip it to set the domain
+	src := NewAuth0TokenSource(server.Client(), server.URL[8:], "test-client-id", "test-client-secret")
+
+	token, err := src.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "test-token" {
+		t.Errorf("expected test-token, got %s", token.AccessToken)
+	}
+	if expiry := time.Until(token.Expiry); expiry < 23*time.Hour || expiry > 24*time.Hour {
+		t.Errorf("expected the token to expire in 24h, got %v", expiry)
+	}
+}
+
+// TestAuth0TokenSource_Error verifies error handling during token fetch.
+func TestAuth0TokenSource_Error(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": "access_denied"}`))
+	}))
+	defer server.Close()
+
+	src := NewAuth0TokenSource(server.Client(), server.URL[8:], "test-client-id", "test-client-secret")
+
+	_, err := fetchToken(context.Background(), src)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	// Verify the error message contains the status code but NOT the body (security)
+	expectedMsg := "failed to fetch token: status 401"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+}
+
+// TestStaticTokenSource verifies that the static token source returns its
+// token without an expiry.
+func TestStaticTokenSource(t *testing.T) {
+	token, err := NewStaticTokenSource("static-token").Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "static-token" {
+		t.Errorf("expected static-token, got %s", token.AccessToken)
+	}
+	if !token.Expiry.IsZero() {
+		t.Errorf("expected no expiry, got %v", token.Expiry)
+	}
+}
+
+// TestFromOAuth2 verifies that an oauth2 token source is adapted with its
+// tokens' access token and expiry, and that its errors are passed through.
+func TestFromOAuth2(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	token, err := FromOAuth2(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "oauth2-token", TokenType: "Bearer", Expiry: expiry})).Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "oauth2-token" {
+		t.Errorf("expected oauth2-token, got %s", token.AccessToken)
+	}
+	if !token.Expiry.Equal(expiry) {
+		t.Errorf("expected expiry %v, got %v", expiry, token.Expiry)
+	}
+
+	src := oauth2.ReuseTokenSource(nil, failingOAuth2Source{})
+	if _, err := FromOAuth2(src).Token(); err == nil || !strings.Contains(err.Error(), "token endpoint unavailable") {
+		t.Errorf("expected the source's error, got %v", err)
+	}
+}
+
+// failingOAuth2Source is an oauth2.TokenSource that always fails.
+type failingOAuth2Source struct{}
+
+func (failingOAuth2Source) Token() (*oauth2.Token, error) {
+	return nil, errors.New("token endpoint unavailable")
+}
+
+// TestCommandTokenSource verifies that the command token source accepts both
+// a bare token and a JSON token response, and reports failing commands.
+func TestCommandTokenSource(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	tests := []struct {
+		name           string
+		script         string
+		expectedToken  string
+		expectedExpiry time.Duration
+		expectError    bool
+	}{
+		{"bare token", "echo command-token", "command-token", defaultCommandTokenLifetime, false},
+		{"JSON token", `echo '{"access_token": "json-token", "expires_in": 3600}'`, "json-token", time.Hour, false},
+		{"JSON without expiry", `echo '{"access_token": "json-token"}'`, "json-token", defaultCommandTokenLifetime, false},
+		{"JSON without token", `echo '{"expires_in": 3600}'`, "", 0, true},
+		{"invalid JSON", `echo '{'`, "", 0, true},
+		{"no output", "true", "", 0, true},
+		{"failure", "echo secret-output; exit 3", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewCommandTokenSource("sh", "-c", tt.script)
+
+			token, err := fetchToken(context.Background(), src)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token.AccessToken != tt.expectedToken {
+				t.Errorf("expected %s, got %s", tt.expectedToken, token.AccessToken)
+			}
+			if expiry := time.Until(token.Expiry); expiry > tt.expectedExpiry || expiry < tt.expectedExpiry-time.Minute {
+				t.Errorf("expected the token to expire in %v, got %v", tt.expectedExpiry, expiry)
+			}
+		})
+	}
+}
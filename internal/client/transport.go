@@ -0,0 +1,117 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// defaultRequestTimeout is the timeout of a single HTTP request when none is configured.
+const defaultRequestTimeout = 30 * time.Second
+
+// TransportConfig configures the HTTP client used for both Grepr API calls and
+// the Auth0 token request. The zero value uses the proxy from the environment
+// (HTTPS_PROXY, HTTP_PROXY, NO_PROXY), the system CA pool and the default
+// request timeout.
+type TransportConfig struct {
+	// ProxyURL, if set, is the proxy all requests are sent through, overriding
+	// the proxy environment variables.
+	ProxyURL string
+
+	// CACertPEM and CACertFile add PEM-encoded CA certificates, given inline or
+	// as a file path, to the system CA pool.
+	CACertPEM  string
+	CACertFile string
+
+	// ClientCertPEM and ClientKeyPEM are a PEM-encoded client certificate and
+	// its private key, presented for mutual TLS. Both or neither must be set.
+	ClientCertPEM string
+	ClientKeyPEM  string
+
+	// InsecureSkipVerify disables verification of server certificates. It
+	// makes connections vulnerable to interception and is only meant for
+	// development against servers with self-signed certificates.
+	InsecureSkipVerify bool
+
+	// Timeout is the timeout of a single HTTP request. Zero uses defaultRequestTimeout.
+	Timeout time.Duration
+}
+
+// NewHTTPClient creates an HTTP client for Config.HTTPClient from cfg.
+func NewHTTPClient(cfg TransportConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: expected a URL such as http://proxy.example.com:3128", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
+
+// tlsConfig builds the TLS configuration for the CA, client certificate and
+// verification settings of cfg.
+func (cfg TransportConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Opt-in for development only; the provider warns when it is enabled.
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACertPEM != "" || cfg.CACertFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if cfg.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(cfg.CACertPEM)) {
+			return nil, fmt.Errorf("no valid PEM certificates found in CA certificate")
+		}
+		if cfg.CACertFile != "" {
+			pem, err := os.ReadFile(cfg.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid PEM certificates found in CA certificate file %s", cfg.CACertFile)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCertPEM != "" || cfg.ClientKeyPEM != "" {
+		if cfg.ClientCertPEM == "" || cfg.ClientKeyPEM == "" {
+			return nil, fmt.Errorf("client certificate and client key must be set together")
+		}
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCertPEM), []byte(cfg.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// generateCertPEM returns a self-signed certificate and its private key, PEM-encoded.
+func generateCertPEM(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "grepr-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+// serverCAPEM returns the PEM-encoded certificate of a TLS test server.
+func serverCAPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+// TestNewHTTPClient_CACert verifies that a server with a private CA is only
+// trusted once its CA certificate is configured, inline or as a file, or
+// when verification is disabled.
+func TestNewHTTPClient_CACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(serverCAPEM(server)), 0o600); err != nil {
+		t.Fatalf("failed to write CA file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		cfg         TransportConfig
+		expectError bool
+	}{
+		{"system pool only", TransportConfig{}, true},
+		{"inline CA", TransportConfig{CACertPEM: serverCAPEM(server)}, false},
+		{"CA file", TransportConfig{CACertFile: caFile}, false},
+		{"insecure", TransportConfig{InsecureSkipVerify: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient, err := NewHTTPClient(tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := httpClient.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error: %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
+
+// TestNewHTTPClient_ClientCert verifies that the configured client
+// certificate is presented to servers that require mutual TLS.
+func TestNewHTTPClient_ClientCert(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			t.Errorf("expected a client certificate")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	certPEM, keyPEM := generateCertPEM(t)
+	httpClient, err := NewHTTPClient(TransportConfig{
+		CACertPEM:     serverCAPEM(server),
+		ClientCertPEM: certPEM,
+		ClientKeyPEM:  keyPEM,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := httpClient.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+}
+
+// TestNewHTTPClient_Proxy verifies that a configured proxy is used for every request.
+func TestNewHTTPClient_Proxy(t *testing.T) {
+	httpClient, err := NewHTTPClient(TransportConfig{ProxyURL: "http://proxy.example.com:3128"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "myorg.app.grepr.ai"}}
+	proxyURL, err := httpClient.Transport.(*http.Transport).Proxy(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+		t.Errorf("expected proxy.example.com:3128, got %v", proxyURL)
+	}
+}
+
+// TestNewHTTPClient_Timeout verifies the request timeout and its default.
+func TestNewHTTPClient_Timeout(t *testing.T) {
+	httpClient, err := NewHTTPClient(TransportConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if httpClient.Timeout != defaultRequestTimeout {
+		t.Errorf("expected default timeout %v, got %v", defaultRequestTimeout, httpClient.Timeout)
+	}
+
+	httpClient, err = NewHTTPClient(TransportConfig{Timeout: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if httpClient.Timeout != time.Minute {
+		t.Errorf("expected timeout 1m, got %v", httpClient.Timeout)
+	}
+}
+
+// TestNewHTTPClient_InvalidConfig verifies that invalid settings are reported
+// when the client is created rather than on the first request.
+func TestNewHTTPClient_InvalidConfig(t *testing.T) {
+	certPEM, keyPEM := generateCertPEM(t)
+	otherCertPEM, _ := generateCertPEM(t)
+
+	tests := []struct {
+		name string
+		cfg  TransportConfig
+	}{
+		{"proxy without scheme", TransportConfig{ProxyURL: "proxy.example.com"}},
+		{"invalid CA PEM", TransportConfig{CACertPEM: "not a certificate"}},
+		{"missing CA file", TransportConfig{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}},
+		{"certificate without key", TransportConfig{ClientCertPEM: certPEM}},
+		{"key without certificate", TransportConfig{ClientKeyPEM: keyPEM}},
+		{"mismatched key", TransportConfig{ClientCertPEM: otherCertPEM, ClientKeyPEM: keyPEM}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewHTTPClient(tt.cfg); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package functions
+
+import (
+	"context"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time check that EdgeFunction implements the function.Function interface
+var _ function.Function = &EdgeFunction{}
+
+// EdgeFunction implements provider::grepr::edge.
+type EdgeFunction struct{}
+
+// NewEdgeFunction creates a new edge function instance.
+func NewEdgeFunction() function.Function {
+	return &EdgeFunction{}
+}
+
+// Metadata returns the function name.
+func (f *EdgeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "edge"
+}
+
+// Definition returns the function signature.
+func (f *EdgeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build a job graph edge",
+		MarkdownDescription: "Returns the edge string connecting two vertices by name, e.g. `\"source -> sink\"`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "from",
+				MarkdownDescription: "The name of the upstream vertex.",
+			},
+			function.StringParameter{
+				Name:                "to",
+				MarkdownDescription: "The name of the downstream vertex.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run returns the edge string.
+func (f *EdgeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var from, to string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &from, &to))
+	if resp.Error != nil {
+		return
+	}
+
+	if from == "" {
+		resp.Error = function.NewArgumentFuncError(0, "from must not be empty")
+		return
+	}
+	if to == "" {
+		resp.Error = function.NewArgumentFuncError(1, "to must not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, jobgraph.FormatEdge(from, to)))
+}
@@ -0,0 +1,99 @@
+// Package functions implements the provider-defined functions of the Grepr
+// provider, available in Terraform 1.8+ as provider::grepr::<name>.
+//
+// The functions help modules compose and check pipeline job graphs without
+// hand-writing jsonencode structures and edge arrow strings:
+//   - edge(from, to) returns an edge string
+//   - graph(vertices, edges) returns a job graph JSON document
+//   - normalize_graph(json) returns the canonical form of a job graph
+//   - validate_graph(json) fails if a job graph is invalid
+//   - render_graph(json, format) renders a job graph as DOT or Mermaid
+//
+// Vertex helper functions such as grok_parser(name, grok_parsing_rules) are
+// derived from the generated OpenAPI vertex schemas, see vertexCatalogue.
+package functions
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// jsonValue converts a Terraform value into the equivalent JSON value, so that
+// objects, tuples and collections of any shape can be passed to functions
+// that build job graphs.
+func jsonValue(ctx context.Context, value attr.Value) (interface{}, error) {
+	tfValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tftypesToJSON(tfValue)
+}
+
+// tftypesToJSON recursively converts a tftypes.Value into plain JSON values.
+func tftypesToJSON(value tftypes.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("value is not known")
+	}
+
+	switch value.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			v, err := tftypesToJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, v)
+		}
+		return result, nil
+	case tftypes.Map, tftypes.Object:
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(attrs))
+		for k, attr := range attrs {
+			v, err := tftypesToJSON(attr)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = v
+		}
+		return result, nil
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case value.Type().Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		if n.IsInt() {
+			i, _ := n.Int(nil)
+			return i, nil
+		}
+		f, _ := n.Float64()
+		return f, nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", value.Type())
+	}
+}
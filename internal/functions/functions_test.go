@@ -0,0 +1,220 @@
+// Package functions provides unit tests for the provider-defined functions.
+package functions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction runs a function with the given arguments and returns its string
+// result or error.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (string, *function.FuncError) {
+	t.Helper()
+
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+// TestEdgeFunction verifies that edge formats an edge and rejects empty names.
+func TestEdgeFunction(t *testing.T) {
+	got, err := runFunction(t, NewEdgeFunction(), types.StringValue("source"), types.StringValue("sink"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "source -> sink" {
+		t.Errorf("expected %q, got %q", "source -> sink", got)
+	}
+
+	if _, err := runFunction(t, NewEdgeFunction(), types.StringValue(""), types.StringValue("sink")); err == nil {
+		t.Error("expected error for empty vertex name")
+	}
+}
+
+// TestGraphFunction verifies that graph builds a job graph from heterogeneous
+// vertex objects and normalizes edge spacing.
+func TestGraphFunction(t *testing.T) {
+	vertices := types.TupleValueMust(
+		[]attr.Type{
+			types.ObjectType{AttrTypes: map[string]attr.Type{"type": types.StringType, "name": types.StringType, "integrationId": types.StringType}},
+			types.ObjectType{AttrTypes: map[string]attr.Type{"type": types.StringType, "name": types.StringType, "grokParsingRules": types.ListType{ElemType: types.StringType}}},
+		},
+		[]attr.Value{
+			types.ObjectValueMust(
+				map[string]attr.Type{"type": types.StringType, "name": types.StringType, "integrationId": types.StringType},
+				map[string]attr.Value{"type": types.StringValue("datadog-log-agent-source"), "name": types.StringValue("source"), "integrationId": types.StringValue("abc")},
+			),
+			types.ObjectValueMust(
+				map[string]attr.Type{"type": types.StringType, "name": types.StringType, "grokParsingRules": types.ListType{ElemType: types.StringType}},
+				map[string]attr.Value{
+					"type":             types.StringValue("grok-parser"),
+					"name":             types.StringValue("parser"),
+					"grokParsingRules": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("%{WORD:word}")}),
+				},
+			),
+		},
+	)
+	edges := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("source->parser")})
+
+	got, err := runFunction(t, NewGraphFunction(), types.DynamicValue(vertices), edges)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"vertices":[{"integrationId":"abc","name":"source","type":"datadog-log-agent-source"},{"grokParsingRules":["%{WORD:word}"],"name":"parser","type":"grok-parser"}],"edges":["source -> parser"]}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if _, err := runFunction(t, NewGraphFunction(), types.DynamicValue(types.StringValue("nope")), edges); err == nil {
+		t.Error("expected error when vertices is not a list")
+	}
+}
+
+// TestNormalizeGraphFunction verifies that normalize_graph sorts keys, vertices
+// and edges.
+func TestNormalizeGraphFunction(t *testing.T) {
+	got, err := runFunction(t, NewNormalizeGraphFunction(), types.StringValue(`{
+		"edges": ["source->sink"],
+		"vertices": [
+			{"type": "logs-iceberg-table-sink", "name": "sink", "datasetId": "ds"},
+			{"name": "source", "type": "datadog-log-agent-source", "integrationId": "abc"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"vertices":[{"datasetId":"ds","name":"sink","type":"logs-iceberg-table-sink"},{"integrationId":"abc","name":"source","type":"datadog-log-agent-source"}],"edges":["source -> sink"]}`
+	if got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	if _, err := runFunction(t, NewNormalizeGraphFunction(), types.StringValue(`{`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+// TestValidateGraphFunction verifies that validate_graph passes valid graphs
+// through and reports issues with their JSON path.
+func TestValidateGraphFunction(t *testing.T) {
+	valid := `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"},{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"}],"edges":["source -> sink"]}`
+	got, err := runFunction(t, NewValidateGraphFunction(), types.StringValue(valid))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != valid {
+		t.Errorf("expected the input to be returned unchanged, got %s", got)
+	}
+
+	invalid := `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"}],"edges":["source -> missing"]}`
+	_, err = runFunction(t, NewValidateGraphFunction(), types.StringValue(invalid))
+	if err == nil {
+		t.Fatal("expected error for invalid graph")
+	}
+	if !strings.Contains(err.Error(), "$.edges[0]") {
+		t.Errorf("expected error to reference $.edges[0], got %v", err)
+	}
+}
+
+// TestVertexFunctions_Catalogue verifies that every vertexCatalogue entry is
+// registered as a function under its name.
+func TestVertexFunctions_Catalogue(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, newFunc := range VertexFunctions() {
+		var metadata function.MetadataResponse
+		newFunc().Metadata(context.Background(), function.MetadataRequest{}, &metadata)
+		registered[metadata.Name] = true
+	}
+
+	for _, entry := range vertexCatalogue {
+		if !registered[entry.name] {
+			t.Errorf("vertex function %s (%s) is not registered", entry.name, entry.vertexType)
+		}
+	}
+	if len(registered) != len(vertexCatalogue) {
+		t.Errorf("expected %d vertex functions, got %d", len(vertexCatalogue), len(registered))
+	}
+}
+
+// TestVertexFunctions verifies that vertex helper functions take their
+// parameters from the vertex schema and return a correctly typed vertex.
+func TestVertexFunctions(t *testing.T) {
+	var grokParser function.Function
+	for _, newFunc := range VertexFunctions() {
+		f := newFunc()
+		var metadata function.MetadataResponse
+		f.Metadata(context.Background(), function.MetadataRequest{}, &metadata)
+		if metadata.Name == "grok_parser" {
+			grokParser = f
+		}
+	}
+	if grokParser == nil {
+		t.Fatal("expected a grok_parser function")
+	}
+
+	var definition function.DefinitionResponse
+	grokParser.Definition(context.Background(), function.DefinitionRequest{}, &definition)
+	params := definition.Definition.Parameters
+	if len(params) < 2 || params[0].GetName() != "name" {
+		t.Fatalf("expected name as the first parameter, got %v", params)
+	}
+
+	args := []attr.Value{types.StringValue("parser")}
+	foundRules := false
+	for _, p := range params[1:] {
+		switch p.GetName() {
+		case "grok_parsing_rules":
+			foundRules = true
+			args = append(args, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("%{WORD:word}")}))
+		default:
+			t.Skipf("grok-parser schema has additional required field %q", p.GetName())
+		}
+	}
+	if !foundRules {
+		t.Fatal("expected a grok_parsing_rules parameter")
+	}
+
+	resp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(definition.Definition.Return.GetType().(types.ObjectType).AttrTypes))}
+	grokParser.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	if resp.Error != nil {
+		t.Fatalf("unexpected error: %v", resp.Error)
+	}
+
+	vertex := resp.Result.Value().(types.Object).Attributes()
+	if vertex["type"].(types.String).ValueString() != "grok-parser" {
+		t.Errorf("expected type grok-parser, got %s", vertex["type"])
+	}
+	if vertex["name"].(types.String).ValueString() != "parser" {
+		t.Errorf("expected name parser, got %s", vertex["name"])
+	}
+	if _, ok := vertex["grokParsingRules"]; !ok {
+		t.Error("expected a grokParsingRules attribute")
+	}
+}
+
+// TestRenderGraphFunction verifies that render_graph renders a job graph and
+// rejects unsupported formats.
+func TestRenderGraphFunction(t *testing.T) {
+	graph := types.StringValue(`{"vertices":[{"type":"grok-parser","name":"parser"}],"edges":[]}`)
+
+	got, err := runFunction(t, NewRenderGraphFunction(), graph, types.StringValue("mermaid"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(got, "flowchart LR\n") {
+		t.Errorf("expected a Mermaid flowchart, got %s", got)
+	}
+
+	if _, err := runFunction(t, NewRenderGraphFunction(), graph, types.StringValue("svg")); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
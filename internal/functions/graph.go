@@ -0,0 +1,93 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Compile-time check that GraphFunction implements the function.Function interface
+var _ function.Function = &GraphFunction{}
+
+// GraphFunction implements provider::grepr::graph.
+type GraphFunction struct{}
+
+// NewGraphFunction creates a new graph function instance.
+func NewGraphFunction() function.Function {
+	return &GraphFunction{}
+}
+
+// Metadata returns the function name.
+func (f *GraphFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "graph"
+}
+
+// Definition returns the function signature.
+func (f *GraphFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a job graph",
+		MarkdownDescription: "Returns a job graph JSON document for `job_graph_json` from a list of vertex objects and a list of edges. " +
+			"Vertex objects use the Grepr API field names (e.g. `{ type = \"grok-parser\", name = \"parser\", grokParsingRules = [...] }`) " +
+			"and may have different shapes. Edges are strings of the form `\"from -> to\"`, see `edge`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "vertices",
+				MarkdownDescription: "A list or tuple of vertex objects.",
+			},
+			function.ListParameter{
+				Name:                "edges",
+				MarkdownDescription: "A list of edges of the form `\"from -> to\"`.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the job graph JSON document.
+func (f *GraphFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var vertices types.Dynamic
+	var edges []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &vertices, &edges))
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := jsonValue(ctx, vertices.UnderlyingValue())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("failed to convert vertices: %s", err))
+		return
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(0, "vertices must be a list or tuple of vertex objects")
+		return
+	}
+
+	graph := jobgraph.Graph{
+		Vertices: make([]map[string]interface{}, 0, len(list)),
+		Edges:    make([]string, 0, len(edges)),
+	}
+	for i, v := range list {
+		vertex, ok := v.(map[string]interface{})
+		if !ok {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("vertex %d must be an object", i))
+			return
+		}
+		graph.Vertices = append(graph.Vertices, vertex)
+	}
+	for _, e := range edges {
+		graph.Edges = append(graph.Edges, jobgraph.NormalizeEdge(e))
+	}
+
+	result, err := jobgraph.Marshal(graph)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to marshal job graph: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,65 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time check that NormalizeGraphFunction implements the function.Function interface
+var _ function.Function = &NormalizeGraphFunction{}
+
+// NormalizeGraphFunction implements provider::grepr::normalize_graph.
+type NormalizeGraphFunction struct{}
+
+// NewNormalizeGraphFunction creates a new normalize_graph function instance.
+func NewNormalizeGraphFunction() function.Function {
+	return &NormalizeGraphFunction{}
+}
+
+// Metadata returns the function name.
+func (f *NormalizeGraphFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_graph"
+}
+
+// Definition returns the function signature.
+func (f *NormalizeGraphFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a job graph",
+		MarkdownDescription: "Returns the canonical form of a job graph JSON document: compact JSON with sorted keys, " +
+			"vertices sorted by name, and edges formatted as `\"from -> to\"` and sorted. " +
+			"Graphs that differ only in ordering or formatting normalize to the same string.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "The job graph JSON document.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the job graph.
+func (f *NormalizeGraphFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jsonStr string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jsonStr))
+	if resp.Error != nil {
+		return
+	}
+
+	graph, err := jobgraph.Parse(jsonStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, err := jobgraph.Marshal(jobgraph.Normalize(graph))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("failed to marshal job graph: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
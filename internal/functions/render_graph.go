@@ -0,0 +1,69 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time check that RenderGraphFunction implements the function.Function interface
+var _ function.Function = &RenderGraphFunction{}
+
+// RenderGraphFunction implements provider::grepr::render_graph.
+type RenderGraphFunction struct{}
+
+// NewRenderGraphFunction creates a new render_graph function instance.
+func NewRenderGraphFunction() function.Function {
+	return &RenderGraphFunction{}
+}
+
+// Metadata returns the function name.
+func (f *RenderGraphFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_graph"
+}
+
+// Definition returns the function signature.
+func (f *RenderGraphFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render a job graph",
+		MarkdownDescription: "Renders the topology of a job graph JSON document as Graphviz DOT (`dot`) or a Mermaid flowchart (`mermaid`), " +
+			"for use in outputs and generated documentation.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "The job graph JSON document.",
+			},
+			function.StringParameter{
+				Name:                "format",
+				MarkdownDescription: "The output format: `" + strings.Join(jobgraph.Formats, "` or `") + "`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run renders the job graph.
+func (f *RenderGraphFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jsonStr, format string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jsonStr, &format))
+	if resp.Error != nil {
+		return
+	}
+
+	graph, err := jobgraph.Parse(jsonStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, err := jobgraph.Render(graph, format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("failed to render job graph: %s", err))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
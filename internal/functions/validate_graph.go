@@ -0,0 +1,69 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Compile-time check that ValidateGraphFunction implements the function.Function interface
+var _ function.Function = &ValidateGraphFunction{}
+
+// ValidateGraphFunction implements provider::grepr::validate_graph.
+type ValidateGraphFunction struct{}
+
+// NewValidateGraphFunction creates a new validate_graph function instance.
+func NewValidateGraphFunction() function.Function {
+	return &ValidateGraphFunction{}
+}
+
+// Metadata returns the function name.
+func (f *ValidateGraphFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_graph"
+}
+
+// Definition returns the function signature.
+func (f *ValidateGraphFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate a job graph",
+		MarkdownDescription: "Checks a job graph JSON document and returns it unchanged if it is valid, so it can wrap the value " +
+			"passed to `job_graph_json`. Fails with every problem found otherwise: duplicate vertex names, edges referencing " +
+			"undefined vertices, edges into sources or out of sinks, cycles, unknown vertex types, and unknown or missing vertex fields.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "The job graph JSON document.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the job graph.
+func (f *ValidateGraphFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var jsonStr string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &jsonStr))
+	if resp.Error != nil {
+		return
+	}
+
+	graph, err := jobgraph.Parse(jsonStr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	if issues := jobgraph.Validate(graph); len(issues) > 0 {
+		lines := make([]string, 0, len(issues))
+		for _, issue := range issues {
+			lines = append(lines, "  - "+issue.String())
+		}
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid job graph:\n%s", strings.Join(lines, "\n")))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, jsonStr))
+}
@@ -0,0 +1,210 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// vertexCatalogue lists the vertex helper functions and the vertex type each
+// one builds. The parameters of each function are derived from the generated
+// OpenAPI schema of its vertex type (see jobgraph.VertexSchema), so adding a
+// helper for a new vertex type only needs a new entry here.
+var vertexCatalogue = []struct {
+	name       string
+	vertexType string
+	summary    string
+}{
+	{name: "datadog_source", vertexType: "datadog-log-agent-source", summary: "Build a Datadog log agent source vertex"},
+	{name: "grok_parser", vertexType: "grok-parser", summary: "Build a grok parser vertex"},
+	{name: "iceberg_sink", vertexType: "logs-iceberg-table-sink", summary: "Build a logs Iceberg table sink vertex"},
+}
+
+// VertexFunctions returns a constructor for each vertex helper function in
+// the catalogue. It panics if a catalogue entry's vertex type is not defined
+// by the generated OpenAPI schema, since the catalogue and the generated code
+// are out of sync and a helper function would otherwise silently disappear.
+func VertexFunctions() []func() function.Function {
+	funcs := make([]func() function.Function, 0, len(vertexCatalogue))
+	for _, entry := range vertexCatalogue {
+		fields, err := jobgraph.VertexSchema(entry.vertexType)
+		if err != nil {
+			panic(fmt.Sprintf("vertex function %s: %v; regenerate the API types or update vertexCatalogue", entry.name, err))
+		}
+
+		f := &VertexFunction{
+			name:       entry.name,
+			vertexType: entry.vertexType,
+			summary:    entry.summary,
+			fields:     vertexFunctionFields(fields),
+		}
+		funcs = append(funcs, func() function.Function { return f })
+	}
+	return funcs
+}
+
+// vertexField is a required field of a vertex schema, exposed as a parameter
+// of its helper function.
+type vertexField struct {
+	jsonName  string
+	paramName string
+	attrType  attr.Type
+}
+
+// vertexFunctionFields returns the parameters of a vertex helper function:
+// `name` first, followed by the other required fields of the schema in
+// declaration order. `type` is set by the function itself.
+func vertexFunctionFields(fields []jobgraph.SchemaField) []vertexField {
+	result := []vertexField{{jsonName: "name", paramName: "name", attrType: types.StringType}}
+	for _, f := range fields {
+		if !f.Required || f.JSONName == "type" || f.JSONName == "name" {
+			continue
+		}
+		result = append(result, vertexField{
+			jsonName:  f.JSONName,
+			paramName: snakeCase(f.JSONName),
+			attrType:  attrTypeOf(f.Type),
+		})
+	}
+	return result
+}
+
+// attrTypeOf returns the Terraform type of a generated schema field.
+// Fields without a direct equivalent accept any value.
+func attrTypeOf(t reflect.Type) attr.Type {
+	switch t.Kind() {
+	case reflect.String:
+		return types.StringType
+	case reflect.Bool:
+		return types.BoolType
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return types.NumberType
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return types.ListType{ElemType: types.StringType}
+		}
+	}
+	return types.DynamicType
+}
+
+// snakeCase converts a camelCase JSON field name into a snake_case parameter name.
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Compile-time check that VertexFunction implements the function.Function interface
+var _ function.Function = &VertexFunction{}
+
+// VertexFunction implements a vertex helper function such as
+// provider::grepr::grok_parser. It returns a vertex object with the correct
+// `type` and the API field names, ready to be passed to `graph`.
+type VertexFunction struct {
+	name       string
+	vertexType string
+	summary    string
+	fields     []vertexField
+}
+
+// Metadata returns the function name.
+func (f *VertexFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+// Definition returns the function signature derived from the vertex schema.
+func (f *VertexFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	params := make([]function.Parameter, 0, len(f.fields))
+	for _, field := range f.fields {
+		description := fmt.Sprintf("The `%s` field of the vertex.", field.jsonName)
+		switch t := field.attrType.(type) {
+		case types.ListType:
+			params = append(params, function.ListParameter{Name: field.paramName, ElementType: t.ElemType, MarkdownDescription: description})
+		case basetypes.StringType:
+			params = append(params, function.StringParameter{Name: field.paramName, MarkdownDescription: description})
+		case basetypes.BoolType:
+			params = append(params, function.BoolParameter{Name: field.paramName, MarkdownDescription: description})
+		case basetypes.NumberType:
+			params = append(params, function.NumberParameter{Name: field.paramName, MarkdownDescription: description})
+		default:
+			params = append(params, function.DynamicParameter{Name: field.paramName, MarkdownDescription: description})
+		}
+	}
+
+	resp.Definition = function.Definition{
+		Summary: f.summary,
+		MarkdownDescription: fmt.Sprintf("Returns a `%s` vertex object for `graph`. ", f.vertexType) +
+			"Optional vertex fields can be added with `merge()`.",
+		Parameters: params,
+		Return:     function.ObjectReturn{AttributeTypes: f.attributeTypes()},
+	}
+}
+
+// Run builds the vertex object from the arguments.
+func (f *VertexFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	values := map[string]attr.Value{"type": types.StringValue(f.vertexType)}
+	for i, field := range f.fields {
+		var value attr.Value
+		switch field.attrType.(type) {
+		case types.ListType:
+			var v types.List
+			resp.Error = req.Arguments.GetArgument(ctx, i, &v)
+			value = v
+		case basetypes.StringType:
+			var v types.String
+			resp.Error = req.Arguments.GetArgument(ctx, i, &v)
+			value = v
+		case basetypes.BoolType:
+			var v types.Bool
+			resp.Error = req.Arguments.GetArgument(ctx, i, &v)
+			value = v
+		case basetypes.NumberType:
+			var v types.Number
+			resp.Error = req.Arguments.GetArgument(ctx, i, &v)
+			value = v
+		default:
+			var v types.Dynamic
+			resp.Error = req.Arguments.GetArgument(ctx, i, &v)
+			value = v
+		}
+		if resp.Error != nil {
+			return
+		}
+		values[field.jsonName] = value
+	}
+
+	vertex, diags := types.ObjectValue(f.attributeTypes(), values)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, vertex))
+}
+
+// attributeTypes returns the attribute types of the returned vertex object.
+func (f *VertexFunction) attributeTypes() map[string]attr.Type {
+	attrTypes := map[string]attr.Type{"type": types.StringType}
+	for _, field := range f.fields {
+		attrTypes[field.jsonName] = field.attrType
+	}
+	return attrTypes
+}
@@ -0,0 +1,124 @@
+// Package jobgraph provides helpers for working with Grepr job graphs
+// independently of how they are configured in Terraform.
+//
+// It is shared by the grepr_pipeline resource, which validates and compares
+// configured graphs, and by the provider functions, which build, normalize
+// and validate graphs in Terraform expressions.
+package jobgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+)
+
+// Graph is a job graph decoded into plain JSON values, giving access to
+// vertex fields regardless of the vertex type.
+type Graph struct {
+	Vertices []map[string]interface{} `json:"vertices"`
+	Edges    []string                 `json:"edges"`
+}
+
+// Decode converts a client.JobGraph into a Graph.
+func Decode(jobGraph *client.JobGraph) (*Graph, error) {
+	raw, err := json.Marshal(jobGraph)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job graph: %w", err)
+	}
+	return Parse(string(raw))
+}
+
+// Parse decodes a job graph JSON document into a Graph.
+func Parse(jsonStr string) (*Graph, error) {
+	var graph Graph
+	if err := json.Unmarshal([]byte(jsonStr), &graph); err != nil {
+		return nil, fmt.Errorf("failed to decode job graph: %w", err)
+	}
+	return &graph, nil
+}
+
+// Marshal encodes a value as compact JSON without escaping HTML characters,
+// so that edges keep their readable "->" arrows.
+func Marshal(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// VertexName returns the name of a vertex, or an empty string if it has none.
+func VertexName(vertex map[string]interface{}) string {
+	name, _ := vertex["name"].(string)
+	return name
+}
+
+// VertexType returns the type of a vertex, or an empty string if it has none.
+func VertexType(vertex map[string]interface{}) string {
+	vertexType, _ := vertex["type"].(string)
+	return vertexType
+}
+
+// FormatEdge returns the edge string connecting two vertices.
+func FormatEdge(from, to string) string {
+	return from + " -> " + to
+}
+
+// ParseEdge splits an edge string of the form "from -> to" into its vertex names.
+func ParseEdge(edge string) (string, string, error) {
+	from, to, ok := strings.Cut(edge, "->")
+	if !ok {
+		return "", "", fmt.Errorf("edge %q must have the form \"from -> to\"", edge)
+	}
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" || to == "" || strings.Contains(to, "->") {
+		return "", "", fmt.Errorf("edge %q must have the form \"from -> to\"", edge)
+	}
+	return from, to, nil
+}
+
+// NormalizeEdge reformats an edge string as "from -> to".
+// Strings that are not in arrow form are returned unchanged.
+func NormalizeEdge(edge string) string {
+	from, to, ok := strings.Cut(edge, "->")
+	if !ok {
+		return edge
+	}
+	return FormatEdge(strings.TrimSpace(from), strings.TrimSpace(to))
+}
+
+// Normalize returns a canonical copy of the graph: vertices sorted by name and
+// edges reformatted as "from -> to" and sorted. Two graphs that differ only in
+// ordering and edge spacing normalize to the same value, and json.Marshal
+// sorts object keys, so the marshaled result is stable.
+func Normalize(graph *Graph) *Graph {
+	normalized := &Graph{
+		Vertices: append([]map[string]interface{}{}, graph.Vertices...),
+		Edges:    make([]string, 0, len(graph.Edges)),
+	}
+	sort.SliceStable(normalized.Vertices, func(i, j int) bool {
+		return VertexName(normalized.Vertices[i]) < VertexName(normalized.Vertices[j])
+	})
+	for _, e := range graph.Edges {
+		normalized.Edges = append(normalized.Edges, NormalizeEdge(e))
+	}
+	sort.Strings(normalized.Edges)
+	return normalized
+}
+
+// IsSourceVertex returns true if the vertex type is a source. Grepr vertex
+// types are suffixed with their role, e.g. datadog-log-agent-source.
+func IsSourceVertex(vertexType string) bool {
+	return strings.HasSuffix(vertexType, "-source")
+}
+
+// IsSinkVertex returns true if the vertex type is a sink, e.g. logs-iceberg-table-sink.
+func IsSinkVertex(vertexType string) bool {
+	return strings.HasSuffix(vertexType, "-sink")
+}
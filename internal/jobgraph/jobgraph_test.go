@@ -0,0 +1,83 @@
+// Package jobgraph provides unit tests for the job graph helpers.
+package jobgraph
+
+import (
+	"testing"
+)
+
+// TestParseEdge verifies that edges are split into their vertex names.
+func TestParseEdge(t *testing.T) {
+	tests := []struct {
+		edge      string
+		from      string
+		to        string
+		expectErr bool
+	}{
+		{edge: "a -> b", from: "a", to: "b"},
+		{edge: "a->b", from: "a", to: "b"},
+		{edge: "  a  ->  b ", from: "a", to: "b"},
+		{edge: "a b", expectErr: true},
+		{edge: "-> b", expectErr: true},
+		{edge: "a ->", expectErr: true},
+		{edge: "a -> b -> c", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.edge, func(t *testing.T) {
+			from, to, err := ParseEdge(tt.edge)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %q -> %q", tt.edge, from, to)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if from != tt.from || to != tt.to {
+				t.Errorf("ParseEdge(%q) = %q, %q, expected %q, %q", tt.edge, from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+// TestNormalizeEdge verifies that edge strings are normalized to "from -> to".
+func TestNormalizeEdge(t *testing.T) {
+	tests := []struct {
+		edge     string
+		expected string
+	}{
+		{"a -> b", "a -> b"},
+		{"a->b", "a -> b"},
+		{"  a   ->b ", "a -> b"},
+		{"not an edge", "not an edge"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.edge, func(t *testing.T) {
+			if got := NormalizeEdge(tt.edge); got != tt.expected {
+				t.Errorf("NormalizeEdge(%q) = %q, expected %q", tt.edge, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNormalize verifies that graphs differing only in ordering and spacing
+// normalize to the same JSON.
+func TestNormalize(t *testing.T) {
+	a, err := Parse(`{"vertices":[{"type":"logs-iceberg-table-sink","name":"sink"},{"name":"source","type":"datadog-log-agent-source"}],"edges":["source->sink"]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := Parse(`{"edges":["source -> sink"],"vertices":[{"type":"datadog-log-agent-source","name":"source"},{"type":"logs-iceberg-table-sink","name":"sink"}]}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	aJSON, _ := Marshal(Normalize(a))
+	bJSON, _ := Marshal(Normalize(b))
+	expected := `{"vertices":[{"name":"sink","type":"logs-iceberg-table-sink"},{"name":"source","type":"datadog-log-agent-source"}],"edges":["source -> sink"]}`
+	if aJSON != expected || bJSON != expected {
+		t.Errorf("expected %s, got %s and %s", expected, aJSON, bJSON)
+	}
+}
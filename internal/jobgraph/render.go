@@ -0,0 +1,137 @@
+package jobgraph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Render formats supported by Render.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+)
+
+// Formats lists the supported render formats.
+var Formats = []string{FormatDOT, FormatMermaid}
+
+// Render returns a text rendering of the graph's topology in the given format.
+// Vertices are labelled with their name and type; sources and sinks get
+// distinct shapes. Output is deterministic: vertices and edges are emitted in
+// the order they are declared.
+func Render(graph *Graph, format string) (string, error) {
+	switch format {
+	case FormatDOT:
+		return RenderDOT(graph), nil
+	case FormatMermaid:
+		return RenderMermaid(graph), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, must be one of: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// renderVertex is a vertex or edge endpoint to render.
+type renderVertex struct {
+	id         string
+	name       string
+	vertexType string
+}
+
+// renderNodes returns one node per vertex of the graph, identified by the
+// vertex's index so that vertices with duplicate or empty names are still
+// drawn separately, followed by one node for each name that is only
+// referenced by an edge. Edges are returned as pairs of node IDs; an edge
+// endpoint refers to the first vertex with that name. Edges that cannot be
+// parsed are skipped.
+func renderNodes(graph *Graph) ([]renderVertex, [][2]string) {
+	nodes := make([]renderVertex, 0, len(graph.Vertices))
+	ids := make(map[string]string)
+	for i, v := range graph.Vertices {
+		id := fmt.Sprintf("v%d", i)
+		name := VertexName(v)
+		if _, ok := ids[name]; !ok {
+			ids[name] = id
+		}
+		nodes = append(nodes, renderVertex{id: id, name: name, vertexType: VertexType(v)})
+	}
+
+	endpoint := func(name string) string {
+		if id, ok := ids[name]; ok {
+			return id
+		}
+		id := fmt.Sprintf("v%d", len(nodes))
+		ids[name] = id
+		nodes = append(nodes, renderVertex{id: id, name: name})
+		return id
+	}
+
+	var edges [][2]string
+	for _, e := range graph.Edges {
+		from, to, err := ParseEdge(e)
+		if err != nil {
+			continue
+		}
+		edges = append(edges, [2]string{endpoint(from), endpoint(to)})
+	}
+
+	return nodes, edges
+}
+
+// RenderDOT renders the graph in the Graphviz DOT language.
+func RenderDOT(graph *Graph) string {
+	nodes, edges := renderNodes(graph)
+	quote := func(s string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+	}
+
+	var b strings.Builder
+	b.WriteString("digraph pipeline {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range nodes {
+		shape := "box"
+		switch {
+		case IsSourceVertex(n.vertexType):
+			shape = "invhouse"
+		case IsSinkVertex(n.vertexType):
+			shape = "cylinder"
+		}
+		label := n.name
+		if n.vertexType != "" {
+			label += "\n" + n.vertexType
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", n.id, quote(label), shape)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", e[0], e[1])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// RenderMermaid renders the graph as a Mermaid flowchart.
+func RenderMermaid(graph *Graph) string {
+	nodes, edges := renderNodes(graph)
+	quote := func(s string) string {
+		return `"` + strings.NewReplacer(`"`, "#quot;", "\n", "<br/>").Replace(s) + `"`
+	}
+
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range nodes {
+		opening, closing := "[", "]"
+		switch {
+		case IsSourceVertex(n.vertexType):
+			opening, closing = "([", "])"
+		case IsSinkVertex(n.vertexType):
+			opening, closing = "[(", ")]"
+		}
+		label := n.name
+		if n.vertexType != "" {
+			label += "\n" + n.vertexType
+		}
+		fmt.Fprintf(&b, "  %s%s%s%s\n", n.id, opening, quote(label), closing)
+	}
+	for _, e := range edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", e[0], e[1])
+	}
+	return b.String()
+}
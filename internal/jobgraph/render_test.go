@@ -0,0 +1,101 @@
+// Package jobgraph provides unit tests for rendering job graphs as DOT and Mermaid.
+package jobgraph
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestRender verifies the DOT and Mermaid output for a small pipeline,
+// including shapes for sources and sinks and vertices only referenced by edges.
+func TestRender(t *testing.T) {
+	graph := &Graph{
+		Vertices: []map[string]interface{}{
+			{"type": "datadog-log-agent-source", "name": "source"},
+			{"type": "grok-parser", "name": "parser"},
+			{"type": "logs-iceberg-table-sink", "name": "sink"},
+		},
+		Edges: []string{"source->parser", "parser -> sink", "parser -> \"other\"", "bad edge"},
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: FormatDOT,
+			expected: `digraph pipeline {
+  rankdir=LR;
+  v0 [label="source\ndatadog-log-agent-source", shape=invhouse];
+  v1 [label="parser\ngrok-parser", shape=box];
+  v2 [label="sink\nlogs-iceberg-table-sink", shape=cylinder];
+  v3 [label="\"other\"", shape=box];
+  v0 -> v1;
+  v1 -> v2;
+  v1 -> v3;
+}
+`,
+		},
+		{
+			format: FormatMermaid,
+			expected: `flowchart LR
+  v0(["source<br/>datadog-log-agent-source"])
+  v1["parser<br/>grok-parser"]
+  v2[("sink<br/>logs-iceberg-table-sink")]
+  v3["#quot;other#quot;"]
+  v0 --> v1
+  v1 --> v2
+  v1 --> v3
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := Render(graph, tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Render(graph, "svg"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+// TestRenderNodes verifies that every vertex gets its own node, even when
+// vertex names are duplicated or missing, and that edges refer to the first
+// vertex with a name.
+func TestRenderNodes(t *testing.T) {
+	graph := &Graph{
+		Vertices: []map[string]interface{}{
+			{"type": "datadog-log-agent-source", "name": "source"},
+			{"type": "grok-parser", "name": "parser"},
+			{"type": "grok-parser", "name": "parser"},
+			{"type": "logs-iceberg-table-sink"},
+		},
+		Edges: []string{"source -> parser", "parser -> missing", "source -> missing"},
+	}
+
+	nodes, edges := renderNodes(graph)
+
+	expectedNodes := []renderVertex{
+		{id: "v0", name: "source", vertexType: "datadog-log-agent-source"},
+		{id: "v1", name: "parser", vertexType: "grok-parser"},
+		{id: "v2", name: "parser", vertexType: "grok-parser"},
+		{id: "v3", name: "", vertexType: "logs-iceberg-table-sink"},
+		{id: "v4", name: "missing"},
+	}
+	if !slices.Equal(nodes, expectedNodes) {
+		t.Errorf("expected nodes %+v, got %+v", expectedNodes, nodes)
+	}
+
+	expectedEdges := [][2]string{{"v0", "v1"}, {"v1", "v4"}, {"v0", "v4"}}
+	if !slices.Equal(edges, expectedEdges) {
+		t.Errorf("expected edges %v, got %v", expectedEdges, edges)
+	}
+}
@@ -0,0 +1,81 @@
+package jobgraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+)
+
+// SchemaField describes a field of a generated vertex schema.
+//
+// Required fields are those the generated struct declares without
+// `omitempty` and as a non-pointer, which is how oapi-codegen marks required
+// properties.
+type SchemaField struct {
+	JSONName string
+	Type     reflect.Type
+	Required bool
+}
+
+// VertexSchema returns the fields of the generated OpenAPI schema for a
+// vertex type, in declaration order.
+func VertexSchema(vertexType string) ([]SchemaField, error) {
+	raw, err := json.Marshal(map[string]string{"type": vertexType})
+	if err != nil {
+		return nil, err
+	}
+	schemaType, err := vertexSchemaType(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a known vertex type: %w", vertexType, err)
+	}
+	return schemaFields(schemaType), nil
+}
+
+// vertexSchemaType returns the generated struct type describing a raw vertex,
+// selected by its `type` discriminator.
+func vertexSchemaType(rawVertex []byte) (reflect.Type, error) {
+	var operation client.Operation
+	if err := json.Unmarshal(rawVertex, &operation); err != nil {
+		return nil, err
+	}
+	value, err := operation.ValueByDiscriminator()
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, fmt.Errorf("unknown vertex type")
+	}
+
+	schemaType := reflect.TypeOf(value)
+	if schemaType.Kind() == reflect.Pointer {
+		schemaType = schemaType.Elem()
+	}
+	if schemaType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unexpected schema type %s", schemaType)
+	}
+	return schemaType, nil
+}
+
+// schemaFields returns the JSON fields of a generated schema struct.
+func schemaFields(t reflect.Type) []SchemaField {
+	var fields []SchemaField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, SchemaField{
+			JSONName: name,
+			Type:     f.Type,
+			Required: !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer,
+		})
+	}
+	return fields
+}
@@ -0,0 +1,315 @@
+package jobgraph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Issue describes a problem found while validating a job graph.
+//
+// Vertex and Edge hold the index of the offending vertex or edge, or -1 when
+// the issue does not relate to one. Field is the offending vertex field, or
+// empty when the issue concerns the vertex or edge as a whole.
+type Issue struct {
+	Vertex  int
+	Edge    int
+	Field   string
+	Summary string
+	Detail  string
+}
+
+// JSONPath returns the location of the issue within the job graph JSON,
+// e.g. $.vertices[1].grokParsingRules or $.edges[0].
+func (i Issue) JSONPath() string {
+	switch {
+	case i.Vertex >= 0 && i.Field != "":
+		return fmt.Sprintf("$.vertices[%d].%s", i.Vertex, i.Field)
+	case i.Vertex >= 0:
+		return fmt.Sprintf("$.vertices[%d]", i.Vertex)
+	case i.Edge >= 0:
+		return fmt.Sprintf("$.edges[%d]", i.Edge)
+	default:
+		return "$"
+	}
+}
+
+// String returns the issue as a single line, prefixed with its JSON path.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.JSONPath(), i.Summary, i.Detail)
+}
+
+// Validate checks the structure of a job graph and the fields of each vertex
+// and returns every problem found. Structural issues are listed first (see
+// validateStructure), followed by the schema issues of each vertex in order
+// (see validateVertex).
+func Validate(graph *Graph) []Issue {
+	issues := validateStructure(graph)
+	for i, vertex := range graph.Vertices {
+		issues = append(issues, validateVertex(i, vertex)...)
+	}
+	return issues
+}
+
+// validateStructure checks the structure of a job graph and returns every
+// problem found:
+// - duplicate vertex names
+// - malformed edges and edges referencing vertices that do not exist
+// - edges into a source vertex or out of a sink vertex
+// - cycles
+func validateStructure(graph *Graph) []Issue {
+	var issues []Issue
+
+	vertexTypes := make(map[string]string, len(graph.Vertices))
+	vertexIndex := make(map[string]int, len(graph.Vertices))
+	for i, v := range graph.Vertices {
+		name := VertexName(v)
+		if name == "" {
+			continue
+		}
+		if first, ok := vertexIndex[name]; ok {
+			issues = append(issues, Issue{
+				Vertex:  i,
+				Edge:    -1,
+				Field:   "name",
+				Summary: "Duplicate Vertex Name",
+				Detail:  fmt.Sprintf("Vertex %d has the name %q, which is already used by vertex %d. Vertex names must be unique within a pipeline.", i, name, first),
+			})
+			continue
+		}
+		vertexTypes[name] = VertexType(v)
+		vertexIndex[name] = i
+	}
+
+	type edgeRef struct {
+		to    string
+		index int
+	}
+	adjacency := make(map[string][]edgeRef)
+
+	for i, edge := range graph.Edges {
+		from, to, err := ParseEdge(edge)
+		if err != nil {
+			issues = append(issues, Issue{Vertex: -1, Edge: i, Summary: "Invalid Edge", Detail: err.Error() + "."})
+			continue
+		}
+
+		valid := true
+		for _, name := range []string{from, to} {
+			if _, ok := vertexTypes[name]; !ok {
+				issues = append(issues, Issue{
+					Vertex:  -1,
+					Edge:    i,
+					Summary: "Unknown Vertex in Edge",
+					Detail:  fmt.Sprintf("Edge %q references vertex %q, which is not defined in the job graph.", edge, name),
+				})
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		if IsSourceVertex(vertexTypes[to]) {
+			issues = append(issues, Issue{
+				Vertex:  -1,
+				Edge:    i,
+				Summary: "Edge Into Source Vertex",
+				Detail:  fmt.Sprintf("Edge %q points into %q, which is a source (%s). Sources cannot have inbound edges.", edge, to, vertexTypes[to]),
+			})
+		}
+		if IsSinkVertex(vertexTypes[from]) {
+			issues = append(issues, Issue{
+				Vertex:  -1,
+				Edge:    i,
+				Summary: "Edge Out of Sink Vertex",
+				Detail:  fmt.Sprintf("Edge %q starts at %q, which is a sink (%s). Sinks cannot have outbound edges.", edge, from, vertexTypes[from]),
+			})
+		}
+
+		adjacency[from] = append(adjacency[from], edgeRef{to: to, index: i})
+	}
+
+	// Depth-first search for cycles. Vertices are visited in declaration order
+	// so that the reported cycles are deterministic.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(vertexIndex))
+	var stack []string
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, e := range adjacency[name] {
+			switch state[e.to] {
+			case unvisited:
+				visit(e.to)
+			case visiting:
+				start := 0
+				for j, n := range stack {
+					if n == e.to {
+						start = j
+						break
+					}
+				}
+				cycle := append(append([]string{}, stack[start:]...), e.to)
+				issues = append(issues, Issue{
+					Vertex:  -1,
+					Edge:    e.index,
+					Summary: "Cycle in Job Graph",
+					Detail:  fmt.Sprintf("The job graph contains a cycle: %s. Job graphs must be acyclic.", strings.Join(cycle, " -> ")),
+				})
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+
+	for _, v := range graph.Vertices {
+		name := VertexName(v)
+		if name != "" && state[name] == unvisited {
+			visit(name)
+		}
+	}
+
+	return issues
+}
+
+// validateVertex strictly decodes the vertex at the given index against the
+// generated OpenAPI schema selected by its `type` discriminator and reports
+// unknown vertex types, unknown fields and missing required fields.
+func validateVertex(index int, vertex map[string]interface{}) []Issue {
+	issue := func(field, summary, detail string) Issue {
+		return Issue{Vertex: index, Edge: -1, Field: field, Summary: summary, Detail: detail}
+	}
+
+	raw, err := json.Marshal(vertex)
+	if err != nil {
+		return []Issue{issue("", "Invalid Vertex", err.Error())}
+	}
+
+	vertexType := VertexType(vertex)
+	if vertexType == "" {
+		return []Issue{issue("type", "Missing Vertex Type", "Every vertex must have a `type`.")}
+	}
+
+	schemaType, err := vertexSchemaType(raw)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		// The type is known but the vertex does not decode into its schema
+		return []Issue{issue(typeErr.Field, "Invalid Vertex Field", fmt.Sprintf("The vertex does not match the %q schema: %s", vertexType, err))}
+	}
+	if err != nil {
+		return []Issue{issue("type", "Unknown Vertex Type", fmt.Sprintf("%q is not a known vertex type.", vertexType))}
+	}
+	var issues []Issue
+	var required []string
+	for _, f := range schemaFields(schemaType) {
+		if f.Required {
+			required = append(required, f.JSONName)
+		}
+	}
+
+	// Unknown fields are all reported, including those of nested objects; the
+	// decode below then catches type mismatches.
+	for _, field := range unknownFields(vertex, schemaType, "") {
+		issues = append(issues, issue(field, "Unknown Vertex Field", fmt.Sprintf("%q is not a field of %q vertices.", field, vertexType)))
+	}
+	if len(issues) == 0 {
+		if err := json.Unmarshal(raw, reflect.New(schemaType).Interface()); err != nil {
+			field := ""
+			if errors.As(err, &typeErr) {
+				field = typeErr.Field
+			}
+			issues = append(issues, issue(field, "Invalid Vertex Field", fmt.Sprintf("The vertex does not match the %q schema: %s", vertexType, err)))
+		}
+	}
+
+	// Missing required fields
+	for _, field := range required {
+		if _, ok := vertex[field]; !ok {
+			issues = append(issues, issue(field, "Missing Vertex Field", fmt.Sprintf("%q is required for %q vertices.", field, vertexType)))
+		}
+	}
+
+	return issues
+}
+
+// sortedKeys returns the keys of a JSON object in sorted order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonUnmarshalerType is the type of json.Unmarshaler.
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the paths of the fields of a decoded JSON value that
+// the schema type t does not declare, in sorted order, descending into nested
+// objects, arrays and maps. Paths are relative to the vertex, e.g.
+// "grokParsingRule" or "options.rules[0].pattern".
+//
+// Values whose shape does not match t are skipped, as are types with custom
+// JSON decoding such as unions, whose fields cannot be derived from the
+// struct; the decode in validateVertex reports those.
+func unknownFields(value interface{}, t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return nil
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields := make(map[string]reflect.Type)
+		for _, f := range schemaFields(t) {
+			fields[f.JSONName] = f.Type
+		}
+		for _, key := range sortedKeys(object) {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			fieldType, ok := fields[key]
+			if !ok {
+				unknown = append(unknown, path)
+				continue
+			}
+			unknown = append(unknown, unknownFields(object[key], fieldType, path)...)
+		}
+	case reflect.Slice, reflect.Array:
+		array, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, element := range array {
+			unknown = append(unknown, unknownFields(element, t.Elem(), fmt.Sprintf("%s[%d]", prefix, i))...)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, key := range sortedKeys(object) {
+			unknown = append(unknown, unknownFields(object[key], t.Elem(), prefix+"."+key)...)
+		}
+	}
+	return unknown
+}
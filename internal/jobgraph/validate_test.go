@@ -0,0 +1,291 @@
+// Package jobgraph provides unit tests for the job graph structure and vertex
+// schema validation.
+package jobgraph
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// TestValidateStructure verifies each structural rule and that issues
+// point at the offending vertex or edge.
+func TestValidateStructure(t *testing.T) {
+	vertex := func(vertexType, name string) map[string]interface{} {
+		return map[string]interface{}{"type": vertexType, "name": name}
+	}
+
+	tests := []struct {
+		name     string
+		graph    Graph
+		expected []Issue
+	}{
+		{
+			name: "valid graph",
+			graph: Graph{
+				Vertices: []map[string]interface{}{
+					vertex("datadog-log-agent-source", "source"),
+					vertex("grok-parser", "parser"),
+					vertex("logs-iceberg-table-sink", "sink"),
+				},
+				Edges: []string{"source -> parser", "parser->sink"},
+			},
+		},
+		{
+			name: "duplicate vertex name",
+			graph: Graph{
+				Vertices: []map[string]interface{}{
+					vertex("grok-parser", "parser"),
+					vertex("grok-parser", "parser"),
+				},
+			},
+			expected: []Issue{{Vertex: 1, Edge: -1, Field: "name", Summary: "Duplicate Vertex Name"}},
+		},
+		{
+			name: "malformed edge",
+			graph: Graph{
+				Vertices: []map[string]interface{}{vertex("grok-parser", "parser")},
+				Edges:    []string{"parser"},
+			},
+			expected: []Issue{{Vertex: -1, Edge: 0, Summary: "Invalid Edge"}},
+		},
+		{
+			name: "edge to nonexistent vertex",
+			graph: Graph{
+				Vertices: []map[string]interface{}{vertex("datadog-log-agent-source", "source")},
+				Edges:    []string{"source -> missing"},
+			},
+			expected: []Issue{{Vertex: -1, Edge: 0, Summary: "Unknown Vertex in Edge"}},
+		},
+		{
+			name: "source with inbound edge",
+			graph: Graph{
+				Vertices: []map[string]interface{}{
+					vertex("grok-parser", "parser"),
+					vertex("datadog-log-agent-source", "source"),
+				},
+				Edges: []string{"parser -> source"},
+			},
+			expected: []Issue{{Vertex: -1, Edge: 0, Summary: "Edge Into Source Vertex"}},
+		},
+		{
+			name: "sink with outbound edge",
+			graph: Graph{
+				Vertices: []map[string]interface{}{
+					vertex("logs-iceberg-table-sink", "sink"),
+					vertex("grok-parser", "parser"),
+				},
+				Edges: []string{"sink -> parser"},
+			},
+			expected: []Issue{{Vertex: -1, Edge: 0, Summary: "Edge Out of Sink Vertex"}},
+		},
+		{
+			name: "cycle",
+			graph: Graph{
+				Vertices: []map[string]interface{}{
+					vertex("datadog-log-agent-source", "source"),
+					vertex("grok-parser", "a"),
+					vertex("grok-parser", "b"),
+				},
+				Edges: []string{"source -> a", "a -> b", "b -> a"},
+			},
+			expected: []Issue{{Vertex: -1, Edge: 2, Summary: "Cycle in Job Graph"}},
+		},
+		{
+			name: "self loop",
+			graph: Graph{
+				Vertices: []map[string]interface{}{vertex("grok-parser", "a")},
+				Edges:    []string{"a -> a"},
+			},
+			expected: []Issue{{Vertex: -1, Edge: 0, Summary: "Cycle in Job Graph"}},
+		},
+		{
+			name: "multiple issues",
+			graph: Graph{
+				Vertices: []map[string]interface{}{
+					vertex("datadog-log-agent-source", "source"),
+					vertex("logs-iceberg-table-sink", "sink"),
+					vertex("logs-iceberg-table-sink", "sink"),
+				},
+				Edges: []string{"source -> sink", "sink -> source", "source -> other"},
+			},
+			expected: []Issue{
+				{Vertex: 2, Edge: -1, Field: "name", Summary: "Duplicate Vertex Name"},
+				{Vertex: -1, Edge: 1, Summary: "Edge Into Source Vertex"},
+				{Vertex: -1, Edge: 1, Summary: "Edge Out of Sink Vertex"},
+				{Vertex: -1, Edge: 2, Summary: "Unknown Vertex in Edge"},
+				{Vertex: -1, Edge: 1, Summary: "Cycle in Job Graph"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := validateStructure(&tt.graph)
+			if len(issues) != len(tt.expected) {
+				t.Fatalf("expected %d issues, got %d: %+v", len(tt.expected), len(issues), issues)
+			}
+			for i, expected := range tt.expected {
+				got := issues[i]
+				if got.Vertex != expected.Vertex || got.Edge != expected.Edge || got.Field != expected.Field || got.Summary != expected.Summary {
+					t.Errorf("issue %d: expected %s, got %s", i, expected.JSONPath()+" "+expected.Summary, got.JSONPath()+" "+got.Summary)
+				}
+				if got.Detail == "" {
+					t.Errorf("issue %d: expected a detail message", i)
+				}
+			}
+		})
+	}
+}
+
+// TestValidateVertex verifies that vertices are checked against the
+// schema selected by their type.
+func TestValidateVertex(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertex   map[string]interface{}
+		expected []Issue
+	}{
+		{
+			name: "valid vertex",
+			vertex: map[string]interface{}{
+				"type":             "grok-parser",
+				"name":             "parser",
+				"grokParsingRules": []interface{}{"%{WORD:word}"},
+			},
+		},
+		{
+			name:     "missing type",
+			vertex:   map[string]interface{}{"name": "parser"},
+			expected: []Issue{{Vertex: 0, Edge: -1, Field: "type", Summary: "Missing Vertex Type"}},
+		},
+		{
+			name:     "unknown type",
+			vertex:   map[string]interface{}{"type": "no-such-vertex", "name": "v"},
+			expected: []Issue{{Vertex: 0, Edge: -1, Field: "type", Summary: "Unknown Vertex Type"}},
+		},
+		{
+			name: "misspelled field",
+			vertex: map[string]interface{}{
+				"type":             "grok-parser",
+				"name":             "parser",
+				"grokParsingRules": []interface{}{"%{WORD:word}"},
+				"grokParsingRule":  []interface{}{"%{WORD:word}"},
+			},
+			expected: []Issue{{Vertex: 0, Edge: -1, Field: "grokParsingRule", Summary: "Unknown Vertex Field"}},
+		},
+		{
+			name: "misspelled field replacing a required one",
+			vertex: map[string]interface{}{
+				"type":            "grok-parser",
+				"name":            "parser",
+				"grokParsingRule": []interface{}{"%{WORD:word}"},
+			},
+			expected: []Issue{
+				{Vertex: 0, Edge: -1, Field: "grokParsingRule", Summary: "Unknown Vertex Field"},
+				{Vertex: 0, Edge: -1, Field: "grokParsingRules", Summary: "Missing Vertex Field"},
+			},
+		},
+		{
+			name: "wrong field type",
+			vertex: map[string]interface{}{
+				"type":             "grok-parser",
+				"name":             "parser",
+				"grokParsingRules": "%{WORD:word}",
+			},
+			expected: []Issue{{Vertex: 0, Edge: -1, Field: "grokParsingRules", Summary: "Invalid Vertex Field"}},
+		},
+		{
+			name:     "missing name",
+			vertex:   map[string]interface{}{"type": "grok-parser", "grokParsingRules": []interface{}{}},
+			expected: []Issue{{Vertex: 0, Edge: -1, Field: "name", Summary: "Missing Vertex Field"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := validateVertex(0, tt.vertex)
+			if len(issues) != len(tt.expected) {
+				t.Fatalf("expected %d issues, got %d: %+v", len(tt.expected), len(issues), issues)
+			}
+			for i, expected := range tt.expected {
+				if issues[i].JSONPath() != expected.JSONPath() || issues[i].Summary != expected.Summary {
+					t.Errorf("issue %d: expected %s %s, got %s %s",
+						i, expected.JSONPath(), expected.Summary, issues[i].JSONPath(), issues[i].Summary)
+				}
+			}
+		})
+	}
+}
+
+// testRule and testSchema mirror the shape of generated vertex schemas with
+// nested objects, arrays, maps and a custom-decoded union.
+type testRule struct {
+	Pattern string  `json:"pattern"`
+	Field   *string `json:"field,omitempty"`
+}
+
+type testUnion struct {
+	union json.RawMessage
+}
+
+func (u *testUnion) UnmarshalJSON(b []byte) error {
+	u.union = b
+	return nil
+}
+
+type testSchema struct {
+	Type    string              `json:"type"`
+	Name    string              `json:"name"`
+	Rules   []testRule          `json:"rules"`
+	Default *testRule           `json:"default,omitempty"`
+	ByName  map[string]testRule `json:"byName,omitempty"`
+	Union   *testUnion          `json:"union,omitempty"`
+}
+
+// TestUnknownFields verifies that unknown fields are found by comparing the
+// decoded JSON against the schema's fields at every level.
+func TestUnknownFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		vertex   string
+		expected []string
+	}{
+		{
+			name:   "known fields",
+			vertex: `{"type": "t", "name": "n", "rules": [{"pattern": "p", "field": "f"}], "default": {"pattern": "p"}, "byName": {"a": {"pattern": "p"}}}`,
+		},
+		{
+			name:     "top-level fields",
+			vertex:   `{"type": "t", "name": "n", "rule": [], "extra": 1}`,
+			expected: []string{"extra", "rule"},
+		},
+		{
+			name:     "nested fields",
+			vertex:   `{"type": "t", "name": "n", "rules": [{"pattern": "p"}, {"patern": "p"}], "default": {"pattern": "p", "x": 1}, "byName": {"a": {"y": 2}}}`,
+			expected: []string{"byName.a.y", "default.x", "rules[1].patern"},
+		},
+		{
+			name:   "custom decoding is not inspected",
+			vertex: `{"type": "t", "name": "n", "union": {"anything": true}}`,
+		},
+		{
+			name:   "mismatched types are skipped",
+			vertex: `{"type": "t", "name": "n", "rules": "p", "default": [1]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var vertex map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.vertex), &vertex); err != nil {
+				t.Fatalf("failed to decode vertex: %v", err)
+			}
+			got := unknownFields(vertex, reflect.TypeOf(testSchema{}), "")
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expected unknown fields %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
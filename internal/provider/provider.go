@@ -1,13 +1,19 @@
 // Package provider implements the Grepr Terraform provider.
 //
 // The provider handles configuration, authentication, and resource registration.
-// It uses OAuth2 client credentials flow via Auth0 to authenticate with the Grepr API.
+// It uses OAuth2 client credentials flow via Auth0 to authenticate with the Grepr API,
+// unless a static access token or access token file is configured.
 //
 // Configuration can be provided via:
-//   - Provider block attributes (host, client_id, client_secret, auth0_domain)
-//   - Environment variables (GREPR_HOST, GREPR_CLIENT_ID, GREPR_CLIENT_SECRET, GREPR_AUTH0_DOMAIN)
+//   - Provider block attributes (host, client_id, client_secret, access_token, access_token_file, auth0_domain,
+//     default_tags, organization_id, ownership_id, max_retries, retry_max_wait, and HTTP transport settings such as http_proxy,
+//     ca_cert_pem and client_cert)
+//   - Environment variables (GREPR_HOST, GREPR_CLIENT_ID, GREPR_CLIENT_SECRET, GREPR_ACCESS_TOKEN,
+//     GREPR_ACCESS_TOKEN_FILE, GREPR_AUTH0_DOMAIN, GREPR_ORGANIZATION_ID, GREPR_OWNERSHIP_ID)
 //
-// Environment variables take precedence over provider block attributes.
+// Provider block attributes take precedence over environment variables. The
+// authentication mode is chosen from the credentials set in the provider block,
+// and the credential environment variables are only considered when it sets none.
 package provider
 
 import (
@@ -16,19 +22,33 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/functions"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/providerdata"
 	"github.com/grepr-ai/terraform-provider-grepr/internal/resources/pipeline"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// Compile-time check that GreprProvider implements the provider.Provider interface.
-var _ provider.Provider = &GreprProvider{}
+// Compile-time checks that GreprProvider implements the provider interfaces.
+var (
+	_ provider.Provider                  = &GreprProvider{}
+	_ provider.ProviderWithFunctions     = &GreprProvider{}
+	_ provider.ProviderWithListResources = &GreprProvider{}
+)
 
 // GreprProvider defines the provider implementation.
 type GreprProvider struct {
@@ -37,10 +57,25 @@ type GreprProvider struct {
 
 // GreprProviderModel describes the provider data model.
 type GreprProviderModel struct {
-	Host         types.String `tfsdk:"host"`
-	ClientID     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
-	Auth0Domain  types.String `tfsdk:"auth0_domain"`
+	Host            types.String `tfsdk:"host"`
+	ClientID        types.String `tfsdk:"client_id"`
+	ClientSecret    types.String `tfsdk:"client_secret"`
+	AccessToken     types.String `tfsdk:"access_token"`
+	AccessTokenFile types.String `tfsdk:"access_token_file"`
+	Auth0Domain     types.String `tfsdk:"auth0_domain"`
+	DefaultTags     types.Map    `tfsdk:"default_tags"`
+	OrganizationID  types.String `tfsdk:"organization_id"`
+	OwnershipID     types.String `tfsdk:"ownership_id"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait    types.Int64  `tfsdk:"retry_max_wait"`
+
+	HTTPProxy          types.String `tfsdk:"http_proxy"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.Int64  `tfsdk:"request_timeout"`
 }
 
 // New creates a new provider instance.
@@ -68,18 +103,98 @@ func (p *GreprProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:            true,
 			},
 			"client_id": schema.StringAttribute{
-				MarkdownDescription: "The OAuth client ID for authentication. Can also be set via the `GREPR_CLIENT_ID` environment variable.",
+				MarkdownDescription: "The OAuth client ID for authentication. Required unless `access_token` or `access_token_file` is set. Can also be set via the `GREPR_CLIENT_ID` environment variable.",
 				Optional:            true,
 			},
 			"client_secret": schema.StringAttribute{
-				MarkdownDescription: "The OAuth client secret for authentication. Can also be set via the `GREPR_CLIENT_SECRET` environment variable.",
+				MarkdownDescription: "The OAuth client secret for authentication. Required unless `access_token` or `access_token_file` is set. Can also be set via the `GREPR_CLIENT_SECRET` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "A bearer token sent with every API request instead of a token obtained from Auth0 with `client_id` and `client_secret`. The token is not refreshed, so it must remain valid for the whole run. Conflicts with `access_token_file`. Can also be set via the `GREPR_ACCESS_TOKEN` environment variable.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("access_token_file")),
+				},
+			},
+			"access_token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing a bearer token, used instead of Auth0. The file is re-read whenever it changes, so a token rotated by another process (such as a workload identity agent) is picked up during long runs. Conflicts with `access_token`. Can also be set via the `GREPR_ACCESS_TOKEN_FILE` environment variable.",
+				Optional:            true,
 			},
 			"auth0_domain": schema.StringAttribute{
 				MarkdownDescription: "The Auth0 domain for OAuth authentication. Defaults to `grepr-prod.us.auth0.com`. Can also be set via the `GREPR_AUTH0_DOMAIN` environment variable.",
 				Optional:            true,
 			},
+			"default_tags": schema.MapAttribute{
+				MarkdownDescription: "Tags applied to every pipeline managed by this provider. Tags set on a resource take precedence over default tags with the same key. The effective tags are shown in each resource's `tags_all` attribute.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the organization this provider manages. If set, importing a pipeline of any other organization fails. Can also be set via the `GREPR_ORGANIZATION_ID` environment variable.",
+				Optional:            true,
+			},
+			"ownership_id": schema.StringAttribute{
+				MarkdownDescription: "Identifies this provider configuration, e.g. the workspace name. It is stored as the value of the `terraform_managed` tag of every pipeline the provider creates, so that `adopt_existing = \"if_tagged\"` only adopts pipelines created with the same `ownership_id`. Defaults to `true`, which is shared by all configurations without an `ownership_id`. Can also be set via the `GREPR_OWNERSHIP_ID` environment variable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a failed API request is retried. Requests are retried on network errors, `429 Too Many Requests` and `5xx` errors; `POST` requests only on `429`. Set to `0` to disable retries. Defaults to `3`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: "Maximum total time in seconds an API request may spend waiting between retries, including waits requested by the server's `Retry-After` header. Defaults to `120`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy to send API and authentication requests through (e.g., `http://proxy.example.com:3128`). Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates to trust in addition to the system CA pool, e.g. for a corporate proxy or a self-hosted Grepr endpoint. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file of PEM-encoded CA certificates to trust in addition to the system CA pool. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate presented for mutual TLS. Requires `client_key`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key of `client_cert`. Requires `client_cert`.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disable verification of TLS certificates. **This makes connections vulnerable to interception and exposes credentials; only use it for development.** Defaults to `false`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds of a single HTTP request. Defaults to `30`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -93,9 +208,11 @@ func (p *GreprProvider) Configure(ctx context.Context, req provider.ConfigureReq
 	}
 
 	host := getConfigValue(config.Host, "GREPR_HOST")
-	clientID := getConfigValue(config.ClientID, "GREPR_CLIENT_ID")
-	clientSecret := getConfigValue(config.ClientSecret, "GREPR_CLIENT_SECRET")
+	creds, diags := resolveCredentials(config)
+	resp.Diagnostics.Append(diags...)
 	auth0Domain := getConfigValue(config.Auth0Domain, "GREPR_AUTH0_DOMAIN")
+	organizationID := getConfigValue(config.OrganizationID, "GREPR_ORGANIZATION_ID")
+	ownershipID := getConfigValue(config.OwnershipID, "GREPR_OWNERSHIP_ID")
 
 	if host == "" {
 		resp.Diagnostics.AddError(
@@ -129,33 +246,78 @@ func (p *GreprProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		}
 	}
 
-	if clientID == "" {
-		resp.Diagnostics.AddError(
-			"Missing Client ID Configuration",
-			"The provider requires a client_id to be configured. Set the `client_id` attribute or the `GREPR_CLIENT_ID` environment variable.",
-		)
+	// default_tags may be unknown during plan, as a whole or per element
+	var defaultTags map[string]string
+	defaultTagsUnknown := config.DefaultTags.IsUnknown()
+	for _, v := range config.DefaultTags.Elements() {
+		defaultTagsUnknown = defaultTagsUnknown || v.IsUnknown()
+	}
+	if !config.DefaultTags.IsNull() && !defaultTagsUnknown {
+		resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
 	}
 
-	if clientSecret == "" {
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	transport := client.TransportConfig{
+		ProxyURL:           config.HTTPProxy.ValueString(),
+		CACertPEM:          config.CACertPEM.ValueString(),
+		CACertFile:         config.CACertFile.ValueString(),
+		ClientCertPEM:      config.ClientCert.ValueString(),
+		ClientKeyPEM:       config.ClientKey.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+		Timeout:            time.Duration(config.RequestTimeout.ValueInt64()) * time.Second,
+	}
+	if transport.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"insecure_skip_verify is enabled: the provider does not verify the TLS certificates of the Grepr API or Auth0. "+
+				"Anyone able to intercept the connection can read the client secret and access tokens and tamper with pipelines. "+
+				"Never use this setting outside of development; configure ca_cert_pem or ca_cert_file to trust a private CA instead.",
+		)
+		tflog.Warn(ctx, "TLS certificate verification is disabled")
+	}
+	httpClient, err := client.NewHTTPClient(transport)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Missing Client Secret Configuration",
-			"The provider requires a client_secret to be configured. Set the `client_secret` attribute or the `GREPR_CLIENT_SECRET` environment variable.",
+			"Invalid HTTP Transport Configuration",
+			fmt.Sprintf("Failed to configure the HTTP client: %s", err.Error()),
 		)
+		return
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	retryPolicy := client.DefaultRetryPolicy()
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		retryPolicy.MaxAttempts = int(config.MaxRetries.ValueInt64()) + 1
+	}
+	if !config.RetryMaxWait.IsNull() && !config.RetryMaxWait.IsUnknown() {
+		retryPolicy.MaxWait = time.Duration(config.RetryMaxWait.ValueInt64()) * time.Second
 	}
 
 	c := client.NewClient(client.Config{
-		Host:         host,
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		Auth0Domain:  auth0Domain,
+		Host:            host,
+		ClientID:        creds.clientID,
+		ClientSecret:    creds.clientSecret,
+		AccessToken:     creds.accessToken,
+		AccessTokenFile: creds.accessTokenFile,
+		Auth0Domain:     auth0Domain,
+		RetryPolicy:     &retryPolicy,
+		HTTPClient:      httpClient,
 	})
 
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	data := &providerdata.ProviderData{
+		Client:             c,
+		DefaultTags:        defaultTags,
+		DefaultTagsUnknown: defaultTagsUnknown,
+		OrganizationID:     organizationID,
+		OwnershipID:        ownershipID,
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
+	resp.ListResourceData = data
 }
 
 // Resources defines the resources implemented by the provider.
@@ -167,13 +329,103 @@ func (p *GreprProvider) Resources(ctx context.Context) []func() resource.Resourc
 
 // DataSources defines the data sources implemented by the provider.
 func (p *GreprProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		pipeline.NewPipelineDataSource,
+		pipeline.NewPipelinesDataSource,
+	}
+}
+
+// ListResources defines the list resources implemented by the provider.
+func (p *GreprProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		pipeline.NewPipelineListResource,
+	}
+}
+
+// Functions defines the provider-defined functions implemented by the provider.
+func (p *GreprProvider) Functions(ctx context.Context) []func() function.Function {
+	return append([]func() function.Function{
+		functions.NewEdgeFunction,
+		functions.NewGraphFunction,
+		functions.NewNormalizeGraphFunction,
+		functions.NewRenderGraphFunction,
+		functions.NewValidateGraphFunction,
+	}, functions.VertexFunctions()...)
 }
 
 // getConfigValue returns the config value if set, otherwise falls back to the environment variable.
 func getConfigValue(configValue types.String, envVar string) string {
-	if !configValue.IsNull() && !configValue.IsUnknown() {
+	if isConfigured(configValue) {
 		return configValue.ValueString()
 	}
 	return os.Getenv(envVar)
 }
+
+// credentials holds the authentication settings resolved from the provider
+// block and the environment. Either a static token setting or the Auth0 client
+// credentials are set, never both.
+type credentials struct {
+	clientID        string
+	clientSecret    string
+	accessToken     string
+	accessTokenFile string
+}
+
+// resolveCredentials picks the authentication mode from the credentials set in
+// the provider block: a static token if access_token or access_token_file is
+// set, otherwise the Auth0 client credentials if client_id or client_secret is
+// set, where the missing one may come from its environment variable. Only when
+// the block sets no credentials is the mode chosen from the environment, in the
+// same order. A stray environment variable therefore never overrides or
+// conflicts with credentials configured explicitly.
+func resolveCredentials(config GreprProviderModel) (credentials, diag.Diagnostics) {
+	var creds credentials
+	var diags diag.Diagnostics
+
+	switch {
+	case isConfigured(config.AccessToken) || isConfigured(config.AccessTokenFile):
+		creds.accessToken = config.AccessToken.ValueString()
+		creds.accessTokenFile = config.AccessTokenFile.ValueString()
+	case isConfigured(config.ClientID) || isConfigured(config.ClientSecret):
+		creds.clientID = getConfigValue(config.ClientID, "GREPR_CLIENT_ID")
+		creds.clientSecret = getConfigValue(config.ClientSecret, "GREPR_CLIENT_SECRET")
+	case os.Getenv("GREPR_ACCESS_TOKEN") != "" || os.Getenv("GREPR_ACCESS_TOKEN_FILE") != "":
+		creds.accessToken = os.Getenv("GREPR_ACCESS_TOKEN")
+		creds.accessTokenFile = os.Getenv("GREPR_ACCESS_TOKEN_FILE")
+	default:
+		creds.clientID = os.Getenv("GREPR_CLIENT_ID")
+		creds.clientSecret = os.Getenv("GREPR_CLIENT_SECRET")
+	}
+
+	if creds.accessToken != "" && creds.accessTokenFile != "" {
+		diags.AddError(
+			"Conflicting Access Token Configuration",
+			"Only one of access_token (`GREPR_ACCESS_TOKEN`) and access_token_file (`GREPR_ACCESS_TOKEN_FILE`) may be set.",
+		)
+	}
+	if creds.accessToken != "" || creds.accessTokenFile != "" {
+		// Static token authentication does not use the Auth0 client credentials.
+		return creds, diags
+	}
+
+	if creds.clientID == "" {
+		diags.AddError(
+			"Missing Client ID Configuration",
+			"The provider requires a client_id to be configured. Set the `client_id` attribute or the `GREPR_CLIENT_ID` environment variable, "+
+				"or authenticate with `access_token` or `access_token_file` instead.",
+		)
+	}
+	if creds.clientSecret == "" {
+		diags.AddError(
+			"Missing Client Secret Configuration",
+			"The provider requires a client_secret to be configured. Set the `client_secret` attribute or the `GREPR_CLIENT_SECRET` environment variable, "+
+				"or authenticate with `access_token` or `access_token_file` instead.",
+		)
+	}
+	return creds, diags
+}
+
+// isConfigured reports whether a provider block attribute is set to a known value.
+func isConfigured(value types.String) bool {
+	return !value.IsNull() && !value.IsUnknown()
+}
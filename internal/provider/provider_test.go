@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestResolveCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        GreprProviderModel
+		env           map[string]string
+		expected      credentials
+		expectedError string
+	}{
+		{
+			name:     "client credentials from config",
+			config:   GreprProviderModel{ClientID: types.StringValue("id"), ClientSecret: types.StringValue("secret")},
+			expected: credentials{clientID: "id", clientSecret: "secret"},
+		},
+		{
+			name:     "config client credentials ignore access token env",
+			config:   GreprProviderModel{ClientID: types.StringValue("id"), ClientSecret: types.StringValue("secret")},
+			env:      map[string]string{"GREPR_ACCESS_TOKEN": "env-token", "GREPR_ACCESS_TOKEN_FILE": "/env/token"},
+			expected: credentials{clientID: "id", clientSecret: "secret"},
+		},
+		{
+			name:     "config client ID with secret from env",
+			config:   GreprProviderModel{ClientID: types.StringValue("id")},
+			env:      map[string]string{"GREPR_CLIENT_SECRET": "env-secret", "GREPR_ACCESS_TOKEN": "env-token"},
+			expected: credentials{clientID: "id", clientSecret: "env-secret"},
+		},
+		{
+			name:          "config client ID without secret",
+			config:        GreprProviderModel{ClientID: types.StringValue("id")},
+			env:           map[string]string{"GREPR_ACCESS_TOKEN": "env-token"},
+			expectedError: "Missing Client Secret Configuration",
+		},
+		{
+			name:     "config access token ignores access token file env",
+			config:   GreprProviderModel{AccessToken: types.StringValue("token")},
+			env:      map[string]string{"GREPR_ACCESS_TOKEN_FILE": "/env/token"},
+			expected: credentials{accessToken: "token"},
+		},
+		{
+			name:     "config access token file ignores access token env",
+			config:   GreprProviderModel{AccessTokenFile: types.StringValue("/config/token")},
+			env:      map[string]string{"GREPR_ACCESS_TOKEN": "env-token"},
+			expected: credentials{accessTokenFile: "/config/token"},
+		},
+		{
+			name:     "config access token ignores client credentials",
+			config:   GreprProviderModel{AccessToken: types.StringValue("token"), ClientID: types.StringValue("id")},
+			env:      map[string]string{"GREPR_CLIENT_SECRET": "env-secret"},
+			expected: credentials{accessToken: "token"},
+		},
+		{
+			name:          "config access token and access token file",
+			config:        GreprProviderModel{AccessToken: types.StringValue("token"), AccessTokenFile: types.StringValue("/config/token")},
+			expectedError: "Conflicting Access Token Configuration",
+		},
+		{
+			name:     "access token from env",
+			env:      map[string]string{"GREPR_ACCESS_TOKEN": "env-token", "GREPR_CLIENT_ID": "env-id"},
+			expected: credentials{accessToken: "env-token"},
+		},
+		{
+			name:          "access token and access token file from env",
+			env:           map[string]string{"GREPR_ACCESS_TOKEN": "env-token", "GREPR_ACCESS_TOKEN_FILE": "/env/token"},
+			expectedError: "Conflicting Access Token Configuration",
+		},
+		{
+			name:     "client credentials from env",
+			env:      map[string]string{"GREPR_CLIENT_ID": "env-id", "GREPR_CLIENT_SECRET": "env-secret"},
+			expected: credentials{clientID: "env-id", clientSecret: "env-secret"},
+		},
+		{
+			name:     "unknown config values fall back to env",
+			config:   GreprProviderModel{AccessToken: types.StringUnknown()},
+			env:      map[string]string{"GREPR_CLIENT_ID": "env-id", "GREPR_CLIENT_SECRET": "env-secret"},
+			expected: credentials{clientID: "env-id", clientSecret: "env-secret"},
+		},
+		{
+			name:          "no credentials",
+			expectedError: "Missing Client ID Configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"GREPR_CLIENT_ID", "GREPR_CLIENT_SECRET", "GREPR_ACCESS_TOKEN", "GREPR_ACCESS_TOKEN_FILE"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			creds, diags := resolveCredentials(tt.config)
+			if tt.expectedError != "" {
+				found := false
+				for _, d := range diags.Errors() {
+					found = found || d.Summary() == tt.expectedError
+				}
+				if !found {
+					t.Fatalf("expected error %q, got %v", tt.expectedError, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if creds != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, creds)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package providerdata defines the data the Grepr provider passes to its
+// resources and data sources from Configure.
+//
+// It lives in its own package so that both the provider and the resource
+// packages can depend on it without an import cycle.
+package providerdata
+
+import (
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+)
+
+// ProviderData is set as ResourceData, DataSourceData, and ListResourceData by GreprProvider.Configure.
+type ProviderData struct {
+	// Client is the configured Grepr API client.
+	Client *client.Client
+
+	// DefaultTags are merged into the tags of every pipeline. Tags set on a
+	// resource take precedence over default tags with the same key.
+	DefaultTags map[string]string
+
+	// DefaultTagsUnknown is true when default_tags is not known yet, e.g.
+	// during a plan where it depends on another resource. DefaultTags is then
+	// empty and tags_all must be planned as unknown.
+	DefaultTagsUnknown bool
+
+	// OrganizationID, if set, is the organization the provider is expected to
+	// manage. Pipelines of other organizations are refused on import.
+	OrganizationID string
+
+	// OwnershipID identifies this provider configuration in the ownership tag
+	// of the pipelines it creates. Empty means the shared default value.
+	OwnershipID string
+}
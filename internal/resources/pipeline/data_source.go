@@ -0,0 +1,186 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Compile-time checks that PipelineDataSource implements required interfaces
+var (
+	_ datasource.DataSource                     = &PipelineDataSource{}
+	_ datasource.DataSourceWithConfigure        = &PipelineDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &PipelineDataSource{}
+)
+
+// PipelineDataSource defines the grepr_pipeline data source implementation.
+//
+// It allows configurations to reference pipelines they do not manage, for
+// example to pass a pipeline ID to another resource.
+type PipelineDataSource struct {
+	client *client.Client
+}
+
+// NewPipelineDataSource creates a new pipeline data source.
+func NewPipelineDataSource() datasource.DataSource {
+	return &PipelineDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *PipelineDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline"
+}
+
+// Schema returns the data source schema.
+func (d *PipelineDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = PipelineDataSourceSchema()
+}
+
+// ConfigValidators ensures exactly one of id or name is configured.
+func (d *PipelineDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+// Configure sets up the data source with the provider client.
+func (d *PipelineDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+// Read looks up the pipeline by ID or name and populates the data source state.
+func (d *PipelineDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PipelineDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var job *client.Job
+	if !config.ID.IsNull() {
+		id := config.ID.ValueString()
+		tflog.Debug(ctx, "Reading pipeline by ID", map[string]interface{}{"id": id})
+
+		found, err := d.client.GetJob(ctx, id)
+		if err != nil {
+			if apiErr, ok := err.(*client.APIError); ok && apiErr.IsNotFound() {
+				resp.Diagnostics.AddError(
+					"Pipeline not found",
+					fmt.Sprintf("No pipeline found with ID: %s", id),
+				)
+				return
+			}
+			resp.Diagnostics.AddError("Failed to read pipeline", err.Error())
+			return
+		}
+		job = found
+	} else {
+		name := config.Name.ValueString()
+		tflog.Debug(ctx, "Reading pipeline by name", map[string]interface{}{"name": name})
+
+		found, err := d.client.GetJobByName(ctx, name)
+		if err != nil {
+			addJobLookupError(&resp.Diagnostics, "Failed to read pipeline", err)
+			return
+		}
+		if found == nil {
+			resp.Diagnostics.AddError(
+				"Pipeline not found",
+				fmt.Sprintf("No pipeline found with name: %s", name),
+			)
+			return
+		}
+		job = found
+	}
+
+	state, err := dataSourceModelFromJob(ctx, job)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read pipeline", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// dataSourceModelFromJob converts an API job into the data source model.
+func dataSourceModelFromJob(ctx context.Context, job *client.Job) (*PipelineDataSourceModel, error) {
+	jobGraphJSON, err := json.Marshal(job.JobGraph)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job graph: %w", err)
+	}
+
+	model := &PipelineDataSourceModel{
+		ID:             types.StringValue(job.Id),
+		Name:           types.StringValue(job.Name),
+		Version:        types.Int64Value(job.Version),
+		State:          types.StringValue(string(job.State)),
+		DesiredState:   types.StringValue(string(job.DesiredState)),
+		JobGraphJSON:   types.StringValue(string(jobGraphJSON)),
+		OrganizationID: types.StringValue(job.OrganizationId),
+		CreatedAt:      types.StringValue(job.CreatedAt.Format(time.RFC3339)),
+		UpdatedAt:      types.StringValue(job.UpdatedAt.Format(time.RFC3339)),
+	}
+
+	tags, teamIDs, err := jobTagsAndTeamIDs(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	model.Tags = tags
+	model.TeamIDs = teamIDs
+
+	return model, nil
+}
+
+// jobTagsAndTeamIDs converts a job's tags and team IDs into Terraform values.
+// Missing values are returned as empty collections rather than null so that
+// data source consumers can iterate over them without null checks. The
+// client's idempotency key tag is internal and left out, while the ownership
+// tag is kept so that pipelines managed by Terraform can be told apart.
+func jobTagsAndTeamIDs(ctx context.Context, job *client.Job) (types.Map, types.Set, error) {
+	tags := make(map[string]string, len(job.Tags))
+	for k, v := range readJobTagsToMap(job.Tags) {
+		if k != client.IdempotencyKeyTag {
+			tags[k] = v
+		}
+	}
+	tagsValue, diags := types.MapValueFrom(ctx, types.StringType, tags)
+	if diags.HasError() {
+		return types.Map{}, types.Set{}, fmt.Errorf("failed to convert tags of pipeline %s", job.Id)
+	}
+
+	teamIDs := []string{}
+	if job.TeamIds != nil {
+		teamIDs = *job.TeamIds
+	}
+	teamIDsValue, diags := types.SetValueFrom(ctx, types.StringType, teamIDs)
+	if diags.HasError() {
+		return types.Map{}, types.Set{}, fmt.Errorf("failed to convert team_ids of pipeline %s", job.Id)
+	}
+
+	return tagsValue, teamIDsValue, nil
+}
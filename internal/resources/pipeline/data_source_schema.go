@@ -0,0 +1,90 @@
+package pipeline
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PipelineDataSourceModel describes the Terraform state data model for the grepr_pipeline data source.
+// This struct maps directly to the HCL attributes defined in PipelineDataSourceSchema().
+type PipelineDataSourceModel struct {
+	// Lookup attributes (exactly one must be set)
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+
+	// Computed attributes
+	Version        types.Int64  `tfsdk:"version"`
+	State          types.String `tfsdk:"state"`
+	DesiredState   types.String `tfsdk:"desired_state"`
+	JobGraphJSON   types.String `tfsdk:"job_graph_json"`
+	Tags           types.Map    `tfsdk:"tags"`
+	TeamIDs        types.Set    `tfsdk:"team_ids"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+// PipelineDataSourceSchema returns the Terraform schema definition for the grepr_pipeline data source.
+//
+// The schema defines:
+// - Lookup attributes: id or name (exactly one must be set)
+// - Computed attributes: everything else, populated from the API
+func PipelineDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Looks up an existing Grepr pipeline (async streaming job) by ID or name.",
+
+		Attributes: map[string]schema.Attribute{
+			// Lookup attributes
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the pipeline (TSID format). Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the pipeline. Exactly one of `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+
+			// Computed attributes (read-only)
+			"version": schema.Int64Attribute{
+				MarkdownDescription: "The current version of the pipeline.",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "The actual current state of the pipeline.",
+				Computed:            true,
+			},
+			"desired_state": schema.StringAttribute{
+				MarkdownDescription: "The desired state of the pipeline (`RUNNING` or `STOPPED`).",
+				Computed:            true,
+			},
+			"job_graph_json": schema.StringAttribute{
+				MarkdownDescription: "The job graph as a JSON string. Use `jsondecode()` to access its vertices and edges.",
+				Computed:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Tags of the pipeline.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"team_ids": schema.SetAttribute{
+				MarkdownDescription: "Set of team IDs that this pipeline is associated with.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "The organization ID that owns this pipeline.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the pipeline was created.",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "The timestamp when the pipeline was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestPipelineDataSource_Read verifies that the grepr_pipeline data source
+// looks pipelines up by ID or by exact name, and reports missing and
+// ambiguous pipelines.
+func TestPipelineDataSource_Read(t *testing.T) {
+	ctx := context.Background()
+
+	api := &stubJobsAPI{jobs: []client.Job{
+		{Id: "job-1", Name: "prod_pipeline", State: client.JobStateRunning, OrganizationId: "org-1", Tags: map[string]string{"env": "prod"}},
+		{Id: "job-2", Name: "prod_pipeline_v2", State: client.JobStateRunning},
+		{Id: "job-3", Name: "dup", State: client.JobStateRunning},
+		{Id: "job-4", Name: "dup", State: client.JobStateStopped},
+		{Id: "job-5", Name: "old_pipeline", State: client.JobStateDeleted},
+	}}
+	d := &PipelineDataSource{client: newStubClient(t, api)}
+
+	tests := []struct {
+		name            string
+		attribute       string
+		value           string
+		expectedID      string
+		expectedSummary string
+	}{
+		{name: "by ID", attribute: "id", value: "job-1", expectedID: "job-1"},
+		{name: "by name", attribute: "name", value: "prod_pipeline", expectedID: "job-1"},
+		{name: "ID not found", attribute: "id", value: "job-9", expectedSummary: "Pipeline not found"},
+		{name: "name not found", attribute: "name", value: "staging", expectedSummary: "Pipeline not found"},
+		{name: "deleted pipeline by name", attribute: "name", value: "old_pipeline", expectedSummary: "Pipeline not found"},
+		{name: "ambiguous name", attribute: "name", value: "dup", expectedSummary: "Ambiguous Pipeline Name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := PipelineDataSourceSchema()
+			req := datasource.ReadRequest{Config: tfsdk.Config{
+				Schema: schema,
+				Raw:    testObjectValue(ctx, schema.Type(), map[string]tftypes.Value{tt.attribute: tftypes.NewValue(tftypes.String, tt.value)}),
+			}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{
+				Schema: schema,
+				Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+			}}
+
+			d.Read(ctx, req, resp)
+
+			if tt.expectedSummary != "" {
+				errs := resp.Diagnostics.Errors()
+				if len(errs) != 1 || errs[0].Summary() != tt.expectedSummary {
+					t.Errorf("expected a %q error, got %v", tt.expectedSummary, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var state PipelineDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("failed to read state: %v", resp.Diagnostics)
+			}
+			if state.ID.ValueString() != tt.expectedID || state.Name.ValueString() != "prod_pipeline" {
+				t.Errorf("expected pipeline %s (prod_pipeline), got %s (%s)", tt.expectedID, state.ID, state.Name)
+			}
+		})
+	}
+}
+
+// TestDataSourceModelFromJob verifies the conversion of a job into the data
+// source model, including empty rather than null tags and team IDs.
+func TestDataSourceModelFromJob(t *testing.T) {
+	ctx := context.Background()
+	teamIDs := []string{"team-1"}
+
+	tests := []struct {
+		name            string
+		job             *client.Job
+		expectedTags    int
+		expectedTeamIDs int
+	}{
+		{
+			name:            "tags and team IDs",
+			job:             &client.Job{Id: "job-1", Name: "p", State: client.JobStateRunning, Tags: map[string]string{"env": "prod", client.IdempotencyKeyTag: "key"}, TeamIds: &teamIDs},
+			expectedTags:    1,
+			expectedTeamIDs: 1,
+		},
+		{
+			name: "no tags or team IDs",
+			job:  &client.Job{Id: "job-2", Name: "q", State: client.JobStateStopped},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := dataSourceModelFromJob(ctx, tt.job)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if model.ID.ValueString() != tt.job.Id || model.State.ValueString() != string(tt.job.State) {
+				t.Errorf("expected id %s and state %s, got %s and %s", tt.job.Id, tt.job.State, model.ID, model.State)
+			}
+			if model.JobGraphJSON.IsNull() {
+				t.Error("expected job_graph_json to be set")
+			}
+			if model.Tags.IsNull() || len(model.Tags.Elements()) != tt.expectedTags {
+				t.Errorf("expected %d tags, got %v", tt.expectedTags, model.Tags)
+			}
+			if model.TeamIDs.IsNull() || len(model.TeamIDs.Elements()) != tt.expectedTeamIDs {
+				t.Errorf("expected %d team IDs, got %v", tt.expectedTeamIDs, model.TeamIDs)
+			}
+		})
+	}
+}
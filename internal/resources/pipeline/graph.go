@@ -0,0 +1,445 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// vertexAttributeFields maps the dedicated vertex block attributes to the
+// JSON field names used by the Grepr API.
+var vertexAttributeFields = map[string]string{
+	"type":               "type",
+	"name":               "name",
+	"integration_id":     "integrationId",
+	"grok_parsing_rules": "grokParsingRules",
+	"dataset_id":         "datasetId",
+}
+
+// usesGraphBlocks returns true if the model defines its job graph with
+// vertex blocks rather than job_graph_json.
+func usesGraphBlocks(model PipelineResourceModel) bool {
+	return !model.Vertices.IsNull() && !model.Vertices.IsUnknown() && len(model.Vertices.Elements()) > 0
+}
+
+// planJobGraph returns the job graph for the plan, built either from
+// job_graph_json or from the vertex and edge blocks.
+func (r *PipelineResource) planJobGraph(ctx context.Context, plan PipelineResourceModel) (*client.JobGraph, error) {
+	if usesGraphBlocks(plan) {
+		jobGraph, err := r.jobGraphFromBlocks(ctx, plan.Vertices, plan.Edges)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert vertex and edge blocks: %w", err)
+		}
+		return jobGraph, nil
+	}
+
+	jobGraph, err := r.parseJobGraph(plan.JobGraphJSON.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse job_graph_json: %w", err)
+	}
+	return jobGraph, nil
+}
+
+// jobGraphFromBlocks converts vertex and edge blocks into a JobGraph.
+//
+// Each vertex block becomes a JSON object with the API field names, merged
+// with any fields from properties_json. Each edge block becomes an
+// "from -> to" edge string. The result is decoded through the generated
+// JobGraph type so that it is validated the same way as job_graph_json.
+func (r *PipelineResource) jobGraphFromBlocks(ctx context.Context, vertexList, edgeList types.List) (*client.JobGraph, error) {
+	var vertexModels []VertexModel
+	if diags := vertexList.ElementsAs(ctx, &vertexModels, false); diags.HasError() {
+		return nil, fmt.Errorf("failed to extract vertex blocks")
+	}
+
+	var edgeModels []EdgeModel
+	if !edgeList.IsNull() && !edgeList.IsUnknown() {
+		if diags := edgeList.ElementsAs(ctx, &edgeModels, false); diags.HasError() {
+			return nil, fmt.Errorf("failed to extract edge blocks")
+		}
+	}
+
+	vertices := make([]map[string]interface{}, 0, len(vertexModels))
+	for i, v := range vertexModels {
+		vertex, err := vertexToMap(ctx, v)
+		if err != nil {
+			return nil, fmt.Errorf("vertex %d (%s): %w", i, v.Name.ValueString(), err)
+		}
+		vertices = append(vertices, vertex)
+	}
+
+	edges := make([]string, 0, len(edgeModels))
+	for _, e := range edgeModels {
+		edges = append(edges, jobgraph.FormatEdge(e.From.ValueString(), e.To.ValueString()))
+	}
+
+	graphJSON, err := json.Marshal(map[string]interface{}{
+		"vertices": vertices,
+		"edges":    edges,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job graph: %w", err)
+	}
+
+	return r.parseJobGraph(string(graphJSON))
+}
+
+// vertexToMap converts a vertex block into the JSON object expected by the API.
+func vertexToMap(ctx context.Context, v VertexModel) (map[string]interface{}, error) {
+	vertex := make(map[string]interface{})
+
+	if !v.PropertiesJSON.IsNull() && v.PropertiesJSON.ValueString() != "" {
+		var properties interface{}
+		if err := json.Unmarshal([]byte(v.PropertiesJSON.ValueString()), &properties); err != nil {
+			return nil, fmt.Errorf("properties_json must be a JSON object: %w", err)
+		}
+		// null, arrays and scalars decode without error but are not objects
+		object, ok := properties.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("properties_json must be a JSON object, got %s", jsonKind(properties))
+		}
+		vertex = object
+		for attr, field := range vertexAttributeFields {
+			if _, ok := vertex[field]; ok {
+				return nil, fmt.Errorf("properties_json must not set %q, use the %s attribute instead", field, attr)
+			}
+		}
+	}
+
+	vertex["type"] = v.Type.ValueString()
+	vertex["name"] = v.Name.ValueString()
+
+	if !v.IntegrationID.IsNull() {
+		vertex["integrationId"] = v.IntegrationID.ValueString()
+	}
+	if !v.DatasetID.IsNull() {
+		vertex["datasetId"] = v.DatasetID.ValueString()
+	}
+	if !v.GrokParsingRules.IsNull() {
+		var rules []string
+		if diags := v.GrokParsingRules.ElementsAs(ctx, &rules, false); diags.HasError() {
+			return nil, fmt.Errorf("failed to extract grok_parsing_rules")
+		}
+		vertex["grokParsingRules"] = rules
+	}
+
+	return vertex, nil
+}
+
+// jsonKind describes the kind of a decoded JSON value for error messages.
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case float64:
+		return "a number"
+	case bool:
+		return "a boolean"
+	default:
+		return "an object"
+	}
+}
+
+// normalizeJobGraph decodes a job graph through client.JobGraph and returns a
+// generic representation suitable for semantic comparison.
+//
+// Vertices are keyed by name so that vertex order does not matter, and edges
+// are reformatted as "from -> to" and sorted so that edge order and spacing
+// around the arrow do not matter.
+func normalizeJobGraph(jobGraph *client.JobGraph) (map[string]interface{}, []string, error) {
+	generic, err := jobgraph.Decode(jobGraph)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vertices := make(map[string]interface{}, len(generic.Vertices))
+	for i, v := range generic.Vertices {
+		name := jobgraph.VertexName(v)
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		vertices[name] = v
+	}
+
+	edges := make([]string, 0, len(generic.Edges))
+	for _, e := range generic.Edges {
+		edges = append(edges, jobgraph.NormalizeEdge(e))
+	}
+	sort.Strings(edges)
+
+	return vertices, edges, nil
+}
+
+// jobGraphsEquivalent reports whether the configured job graph and the job
+// graph returned by the server describe the same pipeline.
+//
+// Key order, vertex order, edge order and fields that only the server sets
+// (defaults it injects) are ignored. A field present in the configuration but
+// absent or different on the server, or a vertex or edge present on only one
+// side, is a meaningful difference.
+func jobGraphsEquivalent(configured, server *client.JobGraph) (bool, error) {
+	configuredVertices, configuredEdges, err := normalizeJobGraph(configured)
+	if err != nil {
+		return false, err
+	}
+	serverVertices, serverEdges, err := normalizeJobGraph(server)
+	if err != nil {
+		return false, err
+	}
+
+	if !reflect.DeepEqual(configuredEdges, serverEdges) || len(configuredVertices) != len(serverVertices) {
+		return false, nil
+	}
+
+	for name, v := range configuredVertices {
+		sv, ok := serverVertices[name]
+		if !ok || !jsonSubset(v, sv) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// jsonSubset reports whether every field in want is present with the same
+// value in got. Objects are compared recursively so that fields added by the
+// server at any depth are ignored; arrays must have the same length.
+func jsonSubset(want, got interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, wv := range w {
+			gv, ok := g[k]
+			if !ok {
+				// A null in the configuration is equivalent to an omitted field
+				if wv == nil {
+					continue
+				}
+				return false
+			}
+			if !jsonSubset(wv, gv) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(w) != len(g) {
+			return false
+		}
+		for i := range w {
+			if !jsonSubset(w[i], g[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(want, got)
+	}
+}
+
+// vertexObjectType and edgeObjectType are the object types of the vertex and
+// edge blocks, used when rebuilding the blocks from a server job graph.
+var (
+	vertexObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"type":               types.StringType,
+		"name":               types.StringType,
+		"integration_id":     types.StringType,
+		"grok_parsing_rules": types.ListType{ElemType: types.StringType},
+		"dataset_id":         types.StringType,
+		"properties_json":    types.StringType,
+	}}
+	edgeObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+		"from": types.StringType,
+		"to":   types.StringType,
+	}}
+)
+
+// refreshJobGraph reconciles the job graph stored in the model with the graph
+// returned by the server during Read.
+//
+// If the two graphs are equivalent (see jobGraphsEquivalent) the stored value is
+// kept, so that server-added defaults never show up as drift. Otherwise the
+// server's graph is written into state, in the same form the user configured it
+// (job_graph_json or vertex/edge blocks), so that the next plan proposes
+// reverting changes made outside Terraform.
+func (r *PipelineResource) refreshJobGraph(ctx context.Context, model *PipelineResourceModel, serverGraph *client.JobGraph) {
+	if usesGraphBlocks(*model) {
+		storedGraph, err := r.jobGraphFromBlocks(ctx, model.Vertices, model.Edges)
+		if err == nil {
+			if equivalent, err := jobGraphsEquivalent(storedGraph, serverGraph); err == nil && equivalent {
+				return
+			}
+		}
+
+		tflog.Info(ctx, "Pipeline job graph was changed outside of Terraform", map[string]interface{}{"id": model.ID.ValueString()})
+
+		vertices, edges, err := blocksFromJobGraph(ctx, serverGraph, model.Vertices)
+		if err != nil {
+			tflog.Warn(ctx, "Failed to convert server job graph to blocks", map[string]interface{}{"error": err.Error()})
+			return
+		}
+		model.Vertices = vertices
+		model.Edges = edges
+		return
+	}
+
+	serverJSON, err := json.Marshal(serverGraph)
+	if err != nil {
+		tflog.Error(ctx, "Failed to marshal server job graph", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	if !model.JobGraphJSON.IsNull() && !model.JobGraphJSON.IsUnknown() {
+		if jobGraphJSONEquivalent(model.JobGraphJSON.ValueString(), string(serverJSON)) {
+			return
+		}
+		tflog.Info(ctx, "Pipeline job graph was changed outside of Terraform", map[string]interface{}{"id": model.ID.ValueString()})
+	}
+
+	model.JobGraphJSON = NewJobGraphJSONValue(string(serverJSON))
+}
+
+// blocksFromJobGraph converts a server job graph into vertex and edge block lists.
+//
+// Vertices that are unchanged relative to the currently stored block with the
+// same name keep that block as-is, so that only the vertices that actually
+// drifted appear in the plan.
+func blocksFromJobGraph(ctx context.Context, jobGraph *client.JobGraph, storedVertices types.List) (types.List, types.List, error) {
+	stored := make(map[string]VertexModel)
+	var storedModels []VertexModel
+	if !storedVertices.IsNull() && !storedVertices.IsUnknown() {
+		if diags := storedVertices.ElementsAs(ctx, &storedModels, false); diags.HasError() {
+			return types.List{}, types.List{}, fmt.Errorf("failed to extract vertex blocks")
+		}
+	}
+	for _, v := range storedModels {
+		stored[v.Name.ValueString()] = v
+	}
+
+	generic, err := jobgraph.Decode(jobGraph)
+	if err != nil {
+		return types.List{}, types.List{}, err
+	}
+
+	vertexModels := make([]VertexModel, 0, len(generic.Vertices))
+	for _, v := range generic.Vertices {
+		name := jobgraph.VertexName(v)
+		if existing, ok := stored[name]; ok {
+			if existingMap, err := vertexToMap(ctx, existing); err == nil && jsonSubset(existingMap, v) {
+				vertexModels = append(vertexModels, existing)
+				continue
+			}
+		}
+
+		model, err := vertexModelFromMap(ctx, v)
+		if err != nil {
+			return types.List{}, types.List{}, fmt.Errorf("vertex %s: %w", name, err)
+		}
+		vertexModels = append(vertexModels, model)
+	}
+
+	edgeModels := make([]EdgeModel, 0, len(generic.Edges))
+	for _, e := range generic.Edges {
+		from, to, _ := strings.Cut(e, "->")
+		edgeModels = append(edgeModels, EdgeModel{
+			From: types.StringValue(strings.TrimSpace(from)),
+			To:   types.StringValue(strings.TrimSpace(to)),
+		})
+	}
+
+	vertices, diags := types.ListValueFrom(ctx, vertexObjectType, vertexModels)
+	if diags.HasError() {
+		return types.List{}, types.List{}, fmt.Errorf("failed to build vertex blocks")
+	}
+	edges, diags := types.ListValueFrom(ctx, edgeObjectType, edgeModels)
+	if diags.HasError() {
+		return types.List{}, types.List{}, fmt.Errorf("failed to build edge blocks")
+	}
+
+	return vertices, edges, nil
+}
+
+// vertexModelFromMap converts an API vertex object into a vertex block,
+// moving fields without a dedicated attribute into properties_json.
+func vertexModelFromMap(ctx context.Context, vertex map[string]interface{}) (VertexModel, error) {
+	model := VertexModel{
+		Type:             types.StringNull(),
+		Name:             types.StringNull(),
+		IntegrationID:    types.StringNull(),
+		GrokParsingRules: types.ListNull(types.StringType),
+		DatasetID:        types.StringNull(),
+		PropertiesJSON:   types.StringNull(),
+	}
+
+	properties := make(map[string]interface{})
+	for field, value := range vertex {
+		s, isString := value.(string)
+		switch {
+		case field == "type" && isString:
+			model.Type = types.StringValue(s)
+		case field == "name" && isString:
+			model.Name = types.StringValue(s)
+		case field == "integrationId" && isString:
+			model.IntegrationID = types.StringValue(s)
+		case field == "datasetId" && isString:
+			model.DatasetID = types.StringValue(s)
+		case field == "grokParsingRules":
+			rules, ok := stringSlice(value)
+			if !ok {
+				properties[field] = value
+				continue
+			}
+			list, diags := types.ListValueFrom(ctx, types.StringType, rules)
+			if diags.HasError() {
+				return VertexModel{}, fmt.Errorf("failed to build grok_parsing_rules")
+			}
+			model.GrokParsingRules = list
+		default:
+			properties[field] = value
+		}
+	}
+
+	if len(properties) > 0 {
+		propertiesJSON, err := json.Marshal(properties)
+		if err != nil {
+			return VertexModel{}, fmt.Errorf("failed to marshal properties_json: %w", err)
+		}
+		model.PropertiesJSON = types.StringValue(string(propertiesJSON))
+	}
+
+	return model, nil
+}
+
+// stringSlice converts a decoded JSON array of strings into a []string.
+func stringSlice(value interface{}) ([]string, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		result = append(result, s)
+	}
+	return result, true
+}
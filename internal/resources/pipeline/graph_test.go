@@ -0,0 +1,399 @@
+// Package pipeline provides unit tests for the job graph helpers used by the
+// grepr_pipeline resource.
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestJobGraphJSONEquivalent verifies that job graphs are compared semantically:
+// cosmetic differences are ignored while real changes are detected.
+func TestJobGraphJSONEquivalent(t *testing.T) {
+	const base = `{
+		"vertices": [
+			{"type": "datadog-log-agent-source", "name": "source", "integrationId": "abc"},
+			{"type": "logs-iceberg-table-sink", "name": "sink", "datasetId": "ds"}
+		],
+		"edges": ["source -> sink"]
+	}`
+
+	tests := []struct {
+		name     string
+		server   string
+		expected bool
+	}{
+		{
+			name:     "identical",
+			server:   base,
+			expected: true,
+		},
+		{
+			name:     "key order and whitespace",
+			server:   `{"edges":["source->sink"],"vertices":[{"name":"source","integrationId":"abc","type":"datadog-log-agent-source"},{"datasetId":"ds","name":"sink","type":"logs-iceberg-table-sink"}]}`,
+			expected: true,
+		},
+		{
+			name:     "vertex order",
+			server:   `{"vertices":[{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"},{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"}],"edges":["source -> sink"]}`,
+			expected: true,
+		},
+		{
+			name:     "server-added default field",
+			server:   `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc","batchSize":100},{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"}],"edges":["source -> sink"]}`,
+			expected: true,
+		},
+		{
+			name:     "changed field",
+			server:   `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"xyz"},{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"}],"edges":["source -> sink"]}`,
+			expected: false,
+		},
+		{
+			name:     "extra vertex",
+			server:   `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"},{"type":"grok-parser","name":"parser"},{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"}],"edges":["source -> sink"]}`,
+			expected: false,
+		},
+		{
+			name:     "changed edge",
+			server:   `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"},{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"}],"edges":["sink -> source"]}`,
+			expected: false,
+		},
+		{
+			name:     "invalid JSON",
+			server:   `{`,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobGraphJSONEquivalent(base, tt.server); got != tt.expected {
+				t.Errorf("jobGraphJSONEquivalent() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRefreshJobGraph verifies that Read keeps the stored job graph when the
+// server's graph is equivalent and writes the server's graph when it drifted.
+func TestRefreshJobGraph(t *testing.T) {
+	const stored = `{"vertices":[{"type":"grok-parser","name":"parser","grokParsingRules":["a"]}],"edges":[]}`
+
+	tests := []struct {
+		name     string
+		server   string
+		expected string
+	}{
+		{
+			name:     "server-added default is not drift",
+			server:   `{"vertices":[{"type":"grok-parser","name":"parser","grokParsingRules":["a"],"enabled":true}],"edges":[]}`,
+			expected: stored,
+		},
+		{
+			name:     "changed field is drift",
+			server:   `{"vertices":[{"type":"grok-parser","name":"parser","grokParsingRules":["b"]}],"edges":[]}`,
+			expected: `{"vertices":[{"type":"grok-parser","name":"parser","grokParsingRules":["b"]}],"edges":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var serverGraph client.JobGraph
+			if err := json.Unmarshal([]byte(tt.server), &serverGraph); err != nil {
+				t.Fatalf("failed to decode server graph: %v", err)
+			}
+
+			model := PipelineResourceModel{JobGraphJSON: NewJobGraphJSONValue(stored)}
+			r := &PipelineResource{}
+			r.refreshJobGraph(context.Background(), &model, &serverGraph)
+
+			if !jobGraphJSONEquivalent(tt.expected, model.JobGraphJSON.ValueString()) ||
+				!jobGraphJSONEquivalent(model.JobGraphJSON.ValueString(), tt.expected) {
+				t.Errorf("expected job_graph_json %s, got %s", tt.expected, model.JobGraphJSON.ValueString())
+			}
+		})
+	}
+}
+
+// testVertex returns a vertex block model with the given type and name and
+// no other attributes.
+func testVertex(vertexType, name string) VertexModel {
+	return VertexModel{
+		Type:             types.StringValue(vertexType),
+		Name:             types.StringValue(name),
+		IntegrationID:    types.StringNull(),
+		GrokParsingRules: types.ListNull(types.StringType),
+		DatasetID:        types.StringNull(),
+		PropertiesJSON:   types.StringNull(),
+	}
+}
+
+// TestVertexToMap verifies that vertex blocks are converted to API vertex
+// objects and that properties_json must be a JSON object without fields that
+// have a dedicated attribute.
+func TestVertexToMap(t *testing.T) {
+	ctx := context.Background()
+
+	withProperties := func(properties string) VertexModel {
+		v := testVertex("grok-parser", "parser")
+		v.PropertiesJSON = types.StringValue(properties)
+		return v
+	}
+	withAttributes := testVertex("logs-iceberg-table-sink", "sink")
+	withAttributes.DatasetID = types.StringValue("ds")
+	withAttributes.GrokParsingRules = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")})
+
+	tests := []struct {
+		name        string
+		vertex      VertexModel
+		expected    map[string]interface{}
+		expectError bool
+	}{
+		{
+			name:     "type and name only",
+			vertex:   testVertex("grok-parser", "parser"),
+			expected: map[string]interface{}{"type": "grok-parser", "name": "parser"},
+		},
+		{
+			name:     "dedicated attributes",
+			vertex:   withAttributes,
+			expected: map[string]interface{}{"type": "logs-iceberg-table-sink", "name": "sink", "datasetId": "ds", "grokParsingRules": []string{"a"}},
+		},
+		{
+			name:     "properties_json merged",
+			vertex:   withProperties(`{"batchSize": 100}`),
+			expected: map[string]interface{}{"type": "grok-parser", "name": "parser", "batchSize": float64(100)},
+		},
+		{
+			name:     "empty properties_json",
+			vertex:   withProperties(""),
+			expected: map[string]interface{}{"type": "grok-parser", "name": "parser"},
+		},
+		{name: "properties_json null", vertex: withProperties("null"), expectError: true},
+		{name: "properties_json array", vertex: withProperties(`[1, 2]`), expectError: true},
+		{name: "properties_json scalar", vertex: withProperties(`"value"`), expectError: true},
+		{name: "properties_json invalid", vertex: withProperties(`{`), expectError: true},
+		{name: "properties_json sets dedicated field", vertex: withProperties(`{"datasetId": "ds"}`), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vertex, err := vertexToMap(ctx, tt.vertex)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected error, got %v", vertex)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(vertex, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, vertex)
+			}
+		})
+	}
+}
+
+// TestJobGraphFromBlocks verifies that vertex and edge blocks are converted
+// into the same job graph as the equivalent job_graph_json.
+func TestJobGraphFromBlocks(t *testing.T) {
+	ctx := context.Background()
+
+	source := testVertex("datadog-log-agent-source", "source")
+	source.IntegrationID = types.StringValue("abc")
+	sink := testVertex("logs-iceberg-table-sink", "sink")
+	sink.DatasetID = types.StringValue("ds")
+	invalid := testVertex("grok-parser", "parser")
+	invalid.PropertiesJSON = types.StringValue("null")
+
+	tests := []struct {
+		name        string
+		vertices    []VertexModel
+		edges       []EdgeModel
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "vertices and edges",
+			vertices: []VertexModel{source, sink},
+			edges:    []EdgeModel{{From: types.StringValue("source"), To: types.StringValue("sink")}},
+			expected: `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"},{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"}],"edges":["source -> sink"]}`,
+		},
+		{
+			name:     "no edges",
+			vertices: []VertexModel{source},
+			expected: `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"}],"edges":[]}`,
+		},
+		{
+			name:        "invalid vertex",
+			vertices:    []VertexModel{source, invalid},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vertices := types.ListValueMust(vertexObjectType, nil)
+			if len(tt.vertices) > 0 {
+				var diags diag.Diagnostics
+				vertices, diags = types.ListValueFrom(ctx, vertexObjectType, tt.vertices)
+				if diags.HasError() {
+					t.Fatalf("failed to build vertex list: %v", diags)
+				}
+			}
+			edges := types.ListNull(edgeObjectType)
+			if len(tt.edges) > 0 {
+				var diags diag.Diagnostics
+				edges, diags = types.ListValueFrom(ctx, edgeObjectType, tt.edges)
+				if diags.HasError() {
+					t.Fatalf("failed to build edge list: %v", diags)
+				}
+			}
+
+			r := &PipelineResource{}
+			jobGraph, err := r.jobGraphFromBlocks(ctx, vertices, edges)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			graphJSON, err := json.Marshal(jobGraph)
+			if err != nil {
+				t.Fatalf("failed to marshal job graph: %v", err)
+			}
+			if !jobGraphJSONEquivalent(tt.expected, string(graphJSON)) || !jobGraphJSONEquivalent(string(graphJSON), tt.expected) {
+				t.Errorf("expected job graph %s, got %s", tt.expected, graphJSON)
+			}
+		})
+	}
+}
+
+// newTestConfig returns a resource configuration with the given attribute and
+// block values; all others are null.
+func newTestConfig(ctx context.Context, values map[string]tftypes.Value) tfsdk.Config {
+	schema := PipelineSchema()
+	return tfsdk.Config{Schema: schema, Raw: testObjectValue(ctx, schema.Type(), values)}
+}
+
+// TestValidateConfig verifies that the job graph must be defined either as
+// job_graph_json or as blocks, and that edges must reference vertices by the
+// names of vertex blocks.
+func TestValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	vertexType := vertexObjectType.TerraformType(ctx).(tftypes.Object)
+	edgeType := edgeObjectType.TerraformType(ctx).(tftypes.Object)
+	vertexBlock := func(vertexTypeName, name string, fields map[string]string) tftypes.Value {
+		values := map[string]tftypes.Value{
+			"type": tftypes.NewValue(tftypes.String, vertexTypeName),
+			"name": tftypes.NewValue(tftypes.String, name),
+		}
+		for attrName, value := range fields {
+			values[attrName] = tftypes.NewValue(tftypes.String, value)
+		}
+		return testObjectValue(ctx, vertexObjectType, values)
+	}
+	vertexBlocks := func(vertices ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(tftypes.List{ElementType: vertexType}, vertices)
+	}
+	edgeBlocks := func(edges ...[2]string) tftypes.Value {
+		values := make([]tftypes.Value, 0, len(edges))
+		for _, e := range edges {
+			values = append(values, tftypes.NewValue(edgeType, map[string]tftypes.Value{
+				"from": tftypes.NewValue(tftypes.String, e[0]),
+				"to":   tftypes.NewValue(tftypes.String, e[1]),
+			}))
+		}
+		return tftypes.NewValue(tftypes.List{ElementType: edgeType}, values)
+	}
+
+	const graphJSON = `{"vertices":[{"type":"datadog-log-agent-source","name":"source","integrationId":"abc"},{"type":"logs-iceberg-table-sink","name":"sink","datasetId":"ds"}],"edges":["source -> sink"]}`
+	source := vertexBlock("datadog-log-agent-source", "source", map[string]string{"integration_id": "abc"})
+	sink := vertexBlock("logs-iceberg-table-sink", "sink", map[string]string{"dataset_id": "ds"})
+
+	tests := []struct {
+		name            string
+		values          map[string]tftypes.Value
+		expectedSummary string
+	}{
+		{
+			name:   "job_graph_json only",
+			values: map[string]tftypes.Value{"job_graph_json": tftypes.NewValue(tftypes.String, graphJSON)},
+		},
+		{
+			name:   "blocks only",
+			values: map[string]tftypes.Value{"vertex": vertexBlocks(source, sink), "edge": edgeBlocks([2]string{"source", "sink"})},
+		},
+		{
+			name: "job_graph_json and vertex blocks",
+			values: map[string]tftypes.Value{
+				"job_graph_json": tftypes.NewValue(tftypes.String, graphJSON),
+				"vertex":         vertexBlocks(source, sink),
+			},
+			expectedSummary: "Conflicting Job Graph Configuration",
+		},
+		{
+			name: "job_graph_json and edge blocks",
+			values: map[string]tftypes.Value{
+				"job_graph_json": tftypes.NewValue(tftypes.String, graphJSON),
+				"edge":           edgeBlocks([2]string{"source", "sink"}),
+			},
+			expectedSummary: "Conflicting Job Graph Configuration",
+		},
+		{
+			name:            "neither",
+			values:          map[string]tftypes.Value{},
+			expectedSummary: "Missing Job Graph Configuration",
+		},
+		{
+			name:            "edge to unknown vertex name",
+			values:          map[string]tftypes.Value{"vertex": vertexBlocks(source, sink), "edge": edgeBlocks([2]string{"source", "sinc"})},
+			expectedSummary: "Unknown Vertex in Edge",
+		},
+		{
+			name:            "duplicate vertex name",
+			values:          map[string]tftypes.Value{"vertex": vertexBlocks(source, sink, sink)},
+			expectedSummary: "Duplicate Vertex Name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: newTestConfig(ctx, tt.values)}
+			resp := &resource.ValidateConfigResponse{}
+			(&PipelineResource{}).ValidateConfig(ctx, req, resp)
+
+			if tt.expectedSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Errorf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+			found := false
+			for _, d := range resp.Diagnostics.Errors() {
+				if d.Summary() == tt.expectedSummary {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected a %q error, got %v", tt.expectedSummary, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/jobgraph"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// validateJobGraph checks the configured job graph (see jobgraph.Validate) and
+// adds an attribute diagnostic for each problem. Issues in vertex and edge
+// blocks are attached to the offending block; issues in job_graph_json are
+// attached to that attribute and prefixed with their JSON path.
+func (r *PipelineResource) validateJobGraph(ctx context.Context, config PipelineResourceModel, diags *diag.Diagnostics) {
+	var graph jobgraph.Graph
+	blocks := usesGraphBlocks(config)
+
+	if blocks {
+		// Blocks derived from unknown values are checked at apply time
+		for _, elem := range append(config.Vertices.Elements(), config.Edges.Elements()...) {
+			value, err := elem.ToTerraformValue(ctx)
+			if err != nil || !value.IsFullyKnown() {
+				return
+			}
+		}
+
+		var vertexModels []VertexModel
+		diags.Append(config.Vertices.ElementsAs(ctx, &vertexModels, false)...)
+		var edgeModels []EdgeModel
+		if !config.Edges.IsNull() {
+			diags.Append(config.Edges.ElementsAs(ctx, &edgeModels, false)...)
+		}
+		if diags.HasError() {
+			return
+		}
+
+		for i, v := range vertexModels {
+			vertex, err := vertexToMap(ctx, v)
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("vertex").AtListIndex(i).AtName("properties_json"),
+					"Invalid Vertex Properties",
+					err.Error(),
+				)
+				return
+			}
+			graph.Vertices = append(graph.Vertices, vertex)
+		}
+		for _, e := range edgeModels {
+			graph.Edges = append(graph.Edges, jobgraph.FormatEdge(e.From.ValueString(), e.To.ValueString()))
+		}
+	} else {
+		if config.JobGraphJSON.IsNull() {
+			return
+		}
+		parsed, err := jobgraph.Parse(config.JobGraphJSON.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("job_graph_json"),
+				"Invalid Job Graph JSON",
+				fmt.Sprintf("job_graph_json must be a JSON object with `vertices` and `edges`: %s", err),
+			)
+			return
+		}
+		graph = *parsed
+	}
+
+	for _, issue := range jobgraph.Validate(&graph) {
+		if !blocks {
+			diags.AddAttributeError(path.Root("job_graph_json"), issue.Summary, fmt.Sprintf("At %s: %s", issue.JSONPath(), issue.Detail))
+			continue
+		}
+
+		issuePath := path.Root("edge").AtListIndex(issue.Edge)
+		if issue.Vertex >= 0 {
+			issuePath = path.Root("vertex").AtListIndex(issue.Vertex)
+			if attr := vertexBlockAttribute(issue.Field); attr != "" {
+				issuePath = issuePath.AtName(attr)
+			}
+		}
+		diags.AddAttributeError(issuePath, issue.Summary, issue.Detail)
+	}
+}
+
+// vertexBlockAttribute returns the vertex block attribute holding a JSON
+// field, or an empty string if the field can only come from properties_json.
+func vertexBlockAttribute(field string) string {
+	for attr, f := range vertexAttributeFields {
+		if f == field {
+			return attr
+		}
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PipelineIdentityModel describes the resource identity of a grepr_pipeline.
+// This struct maps directly to the attributes defined in PipelineIdentitySchema().
+type PipelineIdentityModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// PipelineIdentitySchema returns the resource identity schema for grepr_pipeline.
+//
+// A pipeline is identified by its ID within the organization that owns it.
+func PipelineIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization_id": identityschema.StringAttribute{
+				Description:       "The organization ID that owns the pipeline.",
+				OptionalForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "The unique identifier of the pipeline (TSID format).",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setIdentity writes the identity of job into identity.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, job *client.Job) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, PipelineIdentityModel{
+		OrganizationID: types.StringValue(job.OrganizationId),
+		ID:             types.StringValue(job.Id),
+	})
+}
+
+// checkIdentity verifies that job is the pipeline described by a prior
+// identity. A mismatched organization means the state (or import block) is
+// being used with credentials for a different organization, which would
+// otherwise silently manage the wrong pipeline. A null identity, such as
+// state written before identity support, passes.
+func checkIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, job *client.Job) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if identity == nil || identity.Raw.IsNull() {
+		return diags
+	}
+
+	var model PipelineIdentityModel
+	diags.Append(identity.Get(ctx, &model)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if orgID := model.OrganizationID.ValueString(); orgID != "" && orgID != job.OrganizationId {
+		diags.AddError(
+			"Pipeline organization mismatch",
+			fmt.Sprintf("Pipeline %s belongs to organization %s, but its identity expects organization %s. "+
+				"Check that the provider is configured for the organization this state was created with.",
+				job.Id, job.OrganizationId, orgID),
+		)
+	}
+	if id := model.ID.ValueString(); id != "" && id != job.Id {
+		diags.AddError(
+			"Pipeline identity mismatch",
+			fmt.Sprintf("Found pipeline %s, but its identity expects pipeline %s.", job.Id, id),
+		)
+	}
+	return diags
+}
+
+// identityOrganization returns the organization ID recorded in a prior
+// identity, or an empty string if there is none.
+func identityOrganization(ctx context.Context, identity *tfsdk.ResourceIdentity) (string, diag.Diagnostics) {
+	if identity == nil || identity.Raw.IsNull() {
+		return "", nil
+	}
+
+	var model PipelineIdentityModel
+	diags := identity.Get(ctx, &model)
+	return model.OrganizationID.ValueString(), diags
+}
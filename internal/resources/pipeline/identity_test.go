@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newTestIdentity returns a null resource identity with the pipeline identity schema.
+func newTestIdentity(ctx context.Context) *tfsdk.ResourceIdentity {
+	schema := PipelineIdentitySchema()
+	return &tfsdk.ResourceIdentity{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+// TestCheckIdentity verifies that a prior identity is only accepted for the
+// pipeline and organization it describes.
+func TestCheckIdentity(t *testing.T) {
+	ctx := context.Background()
+	job := &client.Job{Id: "job-1", OrganizationId: "org-1"}
+
+	tests := []struct {
+		name        string
+		identity    *PipelineIdentityModel
+		expectError bool
+	}{
+		{"null identity", nil, false},
+		{"matching identity", &PipelineIdentityModel{OrganizationID: types.StringValue("org-1"), ID: types.StringValue("job-1")}, false},
+		{"import without organization", &PipelineIdentityModel{OrganizationID: types.StringNull(), ID: types.StringValue("job-1")}, false},
+		{"other organization", &PipelineIdentityModel{OrganizationID: types.StringValue("org-2"), ID: types.StringValue("job-1")}, true},
+		{"other pipeline", &PipelineIdentityModel{OrganizationID: types.StringValue("org-1"), ID: types.StringValue("job-2")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := newTestIdentity(ctx)
+			if tt.identity != nil {
+				if diags := identity.Set(ctx, tt.identity); diags.HasError() {
+					t.Fatalf("failed to set identity: %v", diags)
+				}
+			}
+
+			diags := checkIdentity(ctx, identity, job)
+			if diags.HasError() != tt.expectError {
+				t.Errorf("expected error: %v, got %v", tt.expectError, diags)
+			}
+		})
+	}
+}
+
+// TestSetIdentity verifies that setIdentity records the job's organization and ID.
+func TestSetIdentity(t *testing.T) {
+	ctx := context.Background()
+	identity := newTestIdentity(ctx)
+
+	job := &client.Job{Id: "job-1", OrganizationId: "org-1"}
+	if diags := setIdentity(ctx, identity, job); diags.HasError() {
+		t.Fatalf("setIdentity() failed: %v", diags)
+	}
+
+	var model PipelineIdentityModel
+	if diags := identity.Get(ctx, &model); diags.HasError() {
+		t.Fatalf("failed to get identity: %v", diags)
+	}
+	if model.ID.ValueString() != "job-1" || model.OrganizationID.ValueString() != "org-1" {
+		t.Errorf("expected identity {org-1, job-1}, got %+v", model)
+	}
+}
@@ -0,0 +1,141 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Compile-time checks that the custom job graph type implements the framework interfaces
+var (
+	_ basetypes.StringTypable                    = JobGraphJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = JobGraphJSONValue{}
+)
+
+// JobGraphJSONType is the custom string type used for job_graph_json.
+//
+// Values of this type compare job graphs semantically (see jobGraphsEquivalent)
+// so that key order, edge order, whitespace and server-added default fields do
+// not cause spurious diffs, while real changes made outside Terraform do.
+type JobGraphJSONType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t JobGraphJSONType) String() string {
+	return "pipeline.JobGraphJSONType"
+}
+
+// ValueType returns the Value type.
+func (t JobGraphJSONType) ValueType(ctx context.Context) attr.Value {
+	return JobGraphJSONValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t JobGraphJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(JobGraphJSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t JobGraphJSONType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JobGraphJSONValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t JobGraphJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// JobGraphJSONValue is the value of a job_graph_json attribute.
+type JobGraphJSONValue struct {
+	basetypes.StringValue
+}
+
+// NewJobGraphJSONValue creates a known job_graph_json value.
+func NewJobGraphJSONValue(value string) JobGraphJSONValue {
+	return JobGraphJSONValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewJobGraphJSONNull creates a null job_graph_json value.
+func NewJobGraphJSONNull() JobGraphJSONValue {
+	return JobGraphJSONValue{StringValue: basetypes.NewStringNull()}
+}
+
+// Type returns a JobGraphJSONType.
+func (v JobGraphJSONValue) Type(ctx context.Context) attr.Type {
+	return JobGraphJSONType{}
+}
+
+// Equal returns true if the given value is equivalent.
+func (v JobGraphJSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(JobGraphJSONValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals returns true if the given job graph is semantically
+// equal to the current one.
+//
+// The framework calls this with the receiver set to the value returned by the
+// API (the new value) and the argument set to the prior value, which holds the
+// user's configuration. Fields only present on the server side are ignored.
+func (v JobGraphJSONValue) StringSemanticEquals(ctx context.Context, priorValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	priorValue, ok := priorValuable.(JobGraphJSONValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("An unexpected value type was received while performing semantic equality checks. Expected %T, got: %T", v, priorValuable),
+		)
+		return false, diags
+	}
+
+	return jobGraphJSONEquivalent(priorValue.ValueString(), v.ValueString()), diags
+}
+
+// jobGraphJSONEquivalent reports whether two job graph JSON strings are
+// semantically equivalent. Strings that fail to parse are compared verbatim.
+func jobGraphJSONEquivalent(configured, server string) bool {
+	if configured == server {
+		return true
+	}
+
+	var configuredGraph, serverGraph client.JobGraph
+	if err := json.Unmarshal([]byte(configured), &configuredGraph); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(server), &serverGraph); err != nil {
+		return false
+	}
+
+	equal, err := jobGraphsEquivalent(&configuredGraph, &serverGraph)
+	return err == nil && equal
+}
@@ -0,0 +1,134 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Compile-time checks that PipelineListResource implements required interfaces
+var (
+	_ list.ListResource              = &PipelineListResource{}
+	_ list.ListResourceWithConfigure = &PipelineListResource{}
+)
+
+// PipelineListResource defines the grepr_pipeline list resource implementation.
+//
+// It backs `terraform query` by listing the live pipelines in the organization
+// that match the configured name prefix and tags. Each result carries the
+// pipeline's identity and, when requested, its full resource state so that
+// Terraform can generate configuration for it.
+type PipelineListResource struct {
+	client      *client.Client
+	defaultTags map[string]string
+}
+
+// NewPipelineListResource creates a new pipeline list resource.
+func NewPipelineListResource() list.ListResource {
+	return &PipelineListResource{}
+}
+
+// Metadata returns the list resource type name, which matches grepr_pipeline.
+func (r *PipelineListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipeline"
+}
+
+// ListResourceConfigSchema returns the schema of grepr_pipeline list blocks.
+func (r *PipelineListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = PipelineListSchema()
+}
+
+// Configure sets up the list resource with the provider client.
+func (r *PipelineListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.defaultTags = data.DefaultTags
+}
+
+// List streams the live pipelines matching the configured filters.
+//
+// Pages are fetched lazily through client.Jobs, so listing stops requesting
+// pages once Terraform's limit is reached or it stops consuming results.
+func (r *PipelineListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config PipelineListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var opts client.ListJobsOptions
+	if !config.Tags.IsNull() && !config.Tags.IsUnknown() {
+		diags.Append(config.Tags.ElementsAs(ctx, &opts.Tags, false)...)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+
+	filter := pipelineFilter{
+		namePrefix: config.NamePrefix.ValueString(),
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var listed int64
+		for job, err := range r.client.Jobs(ctx, opts) {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError("Failed to list pipelines", err.Error())
+				push(result)
+				return
+			}
+			if client.IsTerminal(job.State) || !filter.matches(&job) {
+				continue
+			}
+			listed++
+
+			// Stop before the iterator requests another page
+			if !push(r.listResult(ctx, req, &job)) || (req.Limit > 0 && listed >= req.Limit) {
+				break
+			}
+		}
+
+		tflog.Debug(ctx, "Listed pipelines", map[string]interface{}{
+			"matched": listed,
+		})
+	}
+}
+
+// listResult converts a job into a list result with its identity and, if
+// requested, its resource state.
+func (r *PipelineListResource) listResult(ctx context.Context, req list.ListRequest, job *client.Job) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = job.Name
+
+	result.Diagnostics.Append(setIdentity(ctx, result.Identity, job)...)
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	if req.IncludeResource {
+		pr := &PipelineResource{client: r.client, defaultTags: r.defaultTags}
+		model := pr.modelFromJob(ctx, job)
+		result.Diagnostics.Append(result.Resource.Set(ctx, &model)...)
+	}
+
+	return result
+}
@@ -0,0 +1,35 @@
+package pipeline
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PipelineListModel describes the configuration of a grepr_pipeline list block.
+// This struct maps directly to the HCL attributes defined in PipelineListSchema().
+type PipelineListModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Tags       types.Map    `tfsdk:"tags"`
+}
+
+// PipelineListSchema returns the Terraform schema definition for grepr_pipeline list blocks.
+//
+// The schema defines:
+// - Optional filters: name_prefix, tags (all must match)
+func PipelineListSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Lists Grepr pipelines (async streaming jobs) for `terraform query`, optionally filtered by name prefix or tags.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list pipelines whose name starts with this prefix.",
+				Optional:            true,
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Only list pipelines that have all of these tags with matching values.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
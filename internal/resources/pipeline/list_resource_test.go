@@ -0,0 +1,198 @@
+package pipeline
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newTestListRequest returns a grepr_pipeline list request with the given
+// configuration values.
+func newTestListRequest(ctx context.Context, values map[string]tftypes.Value, limit int64, includeResource bool) list.ListRequest {
+	schema := PipelineListSchema()
+	return list.ListRequest{
+		Config:                 tfsdk.Config{Schema: schema, Raw: testObjectValue(ctx, schema.Type(), values)},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         PipelineSchema(),
+		ResourceIdentitySchema: PipelineIdentitySchema(),
+	}
+}
+
+// collectListResults runs List and returns its results, consuming at most
+// consume results if consume is positive.
+func collectListResults(t *testing.T, r *PipelineListResource, req list.ListRequest, consume int) []list.ListResult {
+	t.Helper()
+
+	stream := &list.ListResultsStream{}
+	r.List(context.Background(), req, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+		if consume > 0 && len(results) >= consume {
+			break
+		}
+	}
+	return results
+}
+
+// TestPipelineListResource_List verifies the name_prefix, tags and limit
+// filters of the grepr_pipeline list resource, and that terminal pipelines
+// are not listed.
+func TestPipelineListResource_List(t *testing.T) {
+	ctx := context.Background()
+
+	api := &stubJobsAPI{
+		jobs: []client.Job{
+			{Id: "job-1", Name: "prod_a", State: client.JobStateRunning, Tags: map[string]string{"env": "prod", "team": "logs"}},
+			{Id: "job-2", Name: "prod_b", State: client.JobStateStopped, Tags: map[string]string{"env": "prod"}},
+			{Id: "job-3", Name: "prod_old", State: client.JobStateDeleted, Tags: map[string]string{"env": "prod"}},
+			{Id: "job-4", Name: "staging", State: client.JobStateRunning, Tags: map[string]string{"env": "staging", "team": "logs"}},
+		},
+		pageSize: 2,
+	}
+	r := &PipelineListResource{client: newStubClient(t, api)}
+
+	tagsType := tftypes.Map{ElementType: tftypes.String}
+	tests := []struct {
+		name     string
+		values   map[string]tftypes.Value
+		limit    int64
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			values:   map[string]tftypes.Value{},
+			expected: []string{"prod_a", "prod_b", "staging"},
+		},
+		{
+			name:     "name prefix",
+			values:   map[string]tftypes.Value{"name_prefix": tftypes.NewValue(tftypes.String, "prod_")},
+			expected: []string{"prod_a", "prod_b"},
+		},
+		{
+			name: "tags",
+			values: map[string]tftypes.Value{"tags": tftypes.NewValue(tagsType, map[string]tftypes.Value{
+				"team": tftypes.NewValue(tftypes.String, "logs"),
+			})},
+			expected: []string{"prod_a", "staging"},
+		},
+		{
+			name: "name prefix and tags",
+			values: map[string]tftypes.Value{
+				"name_prefix": tftypes.NewValue(tftypes.String, "prod_"),
+				"tags": tftypes.NewValue(tagsType, map[string]tftypes.Value{
+					"env":  tftypes.NewValue(tftypes.String, "prod"),
+					"team": tftypes.NewValue(tftypes.String, "logs"),
+				}),
+			},
+			expected: []string{"prod_a"},
+		},
+		{
+			name:     "limit",
+			values:   map[string]tftypes.Value{},
+			limit:    2,
+			expected: []string{"prod_a", "prod_b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := collectListResults(t, r, newTestListRequest(ctx, tt.values, tt.limit, false), 0)
+
+			var names []string
+			for _, result := range results {
+				names = append(names, result.DisplayName)
+			}
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("expected pipelines %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+// TestPipelineListResource_Pagination verifies that listing stops requesting
+// pages once the limit is reached or Terraform stops consuming results.
+func TestPipelineListResource_Pagination(t *testing.T) {
+	ctx := context.Background()
+
+	jobs := make([]client.Job, 0, 5)
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		jobs = append(jobs, client.Job{Id: "job-" + id, Name: "pipeline_" + id, State: client.JobStateRunning})
+	}
+
+	tests := []struct {
+		name          string
+		limit         int64
+		consume       int
+		expected      int
+		expectedPages int32
+	}{
+		{"all pages", 0, 0, 5, 5},
+		{"limit", 2, 0, 2, 2},
+		{"consumer stops", 0, 3, 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &stubJobsAPI{jobs: jobs, pageSize: 1}
+			r := &PipelineListResource{client: newStubClient(t, api)}
+
+			results := collectListResults(t, r, newTestListRequest(ctx, nil, tt.limit, false), tt.consume)
+			if len(results) != tt.expected {
+				t.Errorf("expected %d results, got %d", tt.expected, len(results))
+			}
+			if got := api.pageRequests.Load(); got != tt.expectedPages {
+				t.Errorf("expected %d page requests, got %d", tt.expectedPages, got)
+			}
+		})
+	}
+}
+
+// TestPipelineListResource_IncludeResource verifies that each result carries
+// the pipeline's identity, and its resource state only when requested.
+func TestPipelineListResource_IncludeResource(t *testing.T) {
+	ctx := context.Background()
+
+	api := &stubJobsAPI{jobs: []client.Job{
+		{Id: "job-1", OrganizationId: "org-1", Name: "prod_a", State: client.JobStateRunning},
+	}}
+	r := &PipelineListResource{client: newStubClient(t, api)}
+
+	for _, includeResource := range []bool{false, true} {
+		results := collectListResults(t, r, newTestListRequest(ctx, nil, 0, includeResource), 0)
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+
+		var identity PipelineIdentityModel
+		if diags := results[0].Identity.Get(ctx, &identity); diags.HasError() {
+			t.Fatalf("failed to read identity: %v", diags)
+		}
+		if identity.ID.ValueString() != "job-1" || identity.OrganizationID.ValueString() != "org-1" {
+			t.Errorf("expected identity job-1 in org-1, got %+v", identity)
+		}
+
+		if results[0].Resource.Raw.IsNull() == includeResource {
+			t.Errorf("expected resource state: %v, got %v", includeResource, results[0].Resource.Raw)
+		}
+		if includeResource {
+			var model PipelineResourceModel
+			if diags := results[0].Resource.Get(ctx, &model); diags.HasError() {
+				t.Fatalf("failed to read resource: %v", diags)
+			}
+			if model.ID.ValueString() != "job-1" || model.Name.ValueString() != "prod_a" {
+				t.Errorf("expected resource job-1 named prod_a, got %s and %s", model.ID, model.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Compile-time checks that PipelinesDataSource implements required interfaces
+var (
+	_ datasource.DataSource              = &PipelinesDataSource{}
+	_ datasource.DataSourceWithConfigure = &PipelinesDataSource{}
+)
+
+// PipelinesDataSource defines the grepr_pipelines data source implementation.
+//
+// It lists the pipelines in the organization matching the configured state and
+// tags, then filters the result by name prefix and team ID. Deleted pipelines
+// are excluded unless include_deleted is set or state is DELETED.
+type PipelinesDataSource struct {
+	client *client.Client
+}
+
+// NewPipelinesDataSource creates a new pipelines data source.
+func NewPipelinesDataSource() datasource.DataSource {
+	return &PipelinesDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *PipelinesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipelines"
+}
+
+// Schema returns the data source schema.
+func (d *PipelinesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = PipelinesDataSourceSchema()
+}
+
+// Configure sets up the data source with the provider client.
+func (d *PipelinesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+// Read lists all pipelines and applies the configured filters.
+func (d *PipelinesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config PipelinesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := client.ListJobsOptions{
+		State: client.JobState(config.State.ValueString()),
+	}
+	if !config.Tags.IsNull() && !config.Tags.IsUnknown() {
+		resp.Diagnostics.Append(config.Tags.ElementsAs(ctx, &opts.Tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	filter := pipelineFilter{
+		namePrefix:     config.NamePrefix.ValueString(),
+		teamID:         config.TeamID.ValueString(),
+		includeDeleted: config.IncludeDeleted.ValueBool() || opts.State == client.JobStateDeleted,
+	}
+
+	jobs, err := d.client.ListJobs(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list pipelines", err.Error())
+		return
+	}
+
+	summaries := make([]PipelineSummaryModel, 0, len(jobs))
+	for i := range jobs {
+		if !filter.matches(&jobs[i]) {
+			continue
+		}
+		summary, err := pipelineSummaryFromJob(ctx, &jobs[i])
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read pipeline", err.Error())
+			return
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name.ValueString() < summaries[j].Name.ValueString()
+	})
+
+	tflog.Debug(ctx, "Listed pipelines", map[string]interface{}{
+		"listed":  len(jobs),
+		"matched": len(summaries),
+	})
+
+	pipelines, diags := types.ListValueFrom(ctx, pipelineSummaryObjectType, summaries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Pipelines = pipelines
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// pipelineFilter applies the grepr_pipelines filters that ListJobsOptions does not cover.
+// Empty fields match every job; all non-empty fields must match. Deleted jobs
+// only match if includeDeleted is set.
+type pipelineFilter struct {
+	namePrefix     string
+	teamID         string
+	includeDeleted bool
+}
+
+// matches returns true if the job satisfies every configured filter.
+func (f pipelineFilter) matches(job *client.Job) bool {
+	if job.State == client.JobStateDeleted && !f.includeDeleted {
+		return false
+	}
+	if f.namePrefix != "" && !strings.HasPrefix(job.Name, f.namePrefix) {
+		return false
+	}
+	if f.teamID != "" {
+		if job.TeamIds == nil {
+			return false
+		}
+		found := false
+		for _, id := range *job.TeamIds {
+			if id == f.teamID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// pipelineSummaryFromJob converts an API job into a pipeline summary.
+func pipelineSummaryFromJob(ctx context.Context, job *client.Job) (PipelineSummaryModel, error) {
+	tagsValue, teamIDsValue, err := jobTagsAndTeamIDs(ctx, job)
+	if err != nil {
+		return PipelineSummaryModel{}, err
+	}
+
+	return PipelineSummaryModel{
+		ID:             types.StringValue(job.Id),
+		Name:           types.StringValue(job.Name),
+		Version:        types.Int64Value(job.Version),
+		State:          types.StringValue(string(job.State)),
+		DesiredState:   types.StringValue(string(job.DesiredState)),
+		Tags:           tagsValue,
+		TeamIDs:        teamIDsValue,
+		OrganizationID: types.StringValue(job.OrganizationId),
+		CreatedAt:      types.StringValue(job.CreatedAt.Format(time.RFC3339)),
+		UpdatedAt:      types.StringValue(job.UpdatedAt.Format(time.RFC3339)),
+	}, nil
+}
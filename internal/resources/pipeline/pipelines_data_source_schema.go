@@ -0,0 +1,143 @@
+package pipeline
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PipelinesDataSourceModel describes the Terraform state data model for the grepr_pipelines data source.
+// This struct maps directly to the HCL attributes defined in PipelinesDataSourceSchema().
+type PipelinesDataSourceModel struct {
+	// Filters
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	State      types.String `tfsdk:"state"`
+	Tags       types.Map    `tfsdk:"tags"`
+	TeamID     types.String `tfsdk:"team_id"`
+
+	IncludeDeleted types.Bool `tfsdk:"include_deleted"`
+
+	// Computed attributes
+	Pipelines types.List `tfsdk:"pipelines"`
+}
+
+// PipelineSummaryModel describes a single pipeline returned by the grepr_pipelines data source.
+type PipelineSummaryModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Version        types.Int64  `tfsdk:"version"`
+	State          types.String `tfsdk:"state"`
+	DesiredState   types.String `tfsdk:"desired_state"`
+	Tags           types.Map    `tfsdk:"tags"`
+	TeamIDs        types.Set    `tfsdk:"team_ids"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+// pipelineSummaryObjectType is the object type of an element of the pipelines attribute.
+var pipelineSummaryObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":              types.StringType,
+	"name":            types.StringType,
+	"version":         types.Int64Type,
+	"state":           types.StringType,
+	"desired_state":   types.StringType,
+	"tags":            types.MapType{ElemType: types.StringType},
+	"team_ids":        types.SetType{ElemType: types.StringType},
+	"organization_id": types.StringType,
+	"created_at":      types.StringType,
+	"updated_at":      types.StringType,
+}}
+
+// PipelinesDataSourceSchema returns the Terraform schema definition for the grepr_pipelines data source.
+//
+// The schema defines:
+// - Optional filters: name_prefix, state, tags, team_id (all must match), include_deleted
+// - Computed attributes: pipelines, a list of pipeline summaries sorted by name
+func PipelinesDataSourceSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Lists Grepr pipelines (async streaming jobs), optionally filtered by name prefix, state, tags, or team.",
+
+		Attributes: map[string]schema.Attribute{
+			// Filters
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only return pipelines whose name starts with this prefix.",
+				Optional:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Only return pipelines in this state (e.g., `RUNNING`, `STOPPED`, `FAILED`).",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"tags": schema.MapAttribute{
+				MarkdownDescription: "Only return pipelines that have all of these tags with matching values.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"team_id": schema.StringAttribute{
+				MarkdownDescription: "Only return pipelines associated with this team ID.",
+				Optional:            true,
+			},
+			"include_deleted": schema.BoolAttribute{
+				MarkdownDescription: "Whether to return pipelines in the `DELETED` state. Defaults to `false`, unless `state` is `DELETED`.",
+				Optional:            true,
+			},
+
+			// Computed attributes (read-only)
+			"pipelines": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching pipelines, sorted by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the pipeline (TSID format).",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the pipeline.",
+							Computed:            true,
+						},
+						"version": schema.Int64Attribute{
+							MarkdownDescription: "The current version of the pipeline.",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "The actual current state of the pipeline.",
+							Computed:            true,
+						},
+						"desired_state": schema.StringAttribute{
+							MarkdownDescription: "The desired state of the pipeline.",
+							Computed:            true,
+						},
+						"tags": schema.MapAttribute{
+							MarkdownDescription: "Tags of the pipeline.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"team_ids": schema.SetAttribute{
+							MarkdownDescription: "Set of team IDs that this pipeline is associated with.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"organization_id": schema.StringAttribute{
+							MarkdownDescription: "The organization ID that owns this pipeline.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the pipeline was created.",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the pipeline was last updated.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
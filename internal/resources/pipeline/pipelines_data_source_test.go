@@ -0,0 +1,135 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestPipelineFilter verifies the name prefix, team ID and deleted state
+// filters of grepr_pipelines.
+func TestPipelineFilter(t *testing.T) {
+	teamIDs := []string{"team-1", "team-2"}
+	job := &client.Job{Name: "prod_pipeline", State: client.JobStateRunning, TeamIds: &teamIDs}
+	noTeams := &client.Job{Name: "prod_pipeline", State: client.JobStateRunning}
+	deleted := &client.Job{Name: "prod_pipeline", State: client.JobStateDeleted, TeamIds: &teamIDs}
+
+	tests := []struct {
+		name     string
+		filter   pipelineFilter
+		job      *client.Job
+		expected bool
+	}{
+		{"no filters", pipelineFilter{}, job, true},
+		{"matching prefix", pipelineFilter{namePrefix: "prod_"}, job, true},
+		{"full name as prefix", pipelineFilter{namePrefix: "prod_pipeline"}, job, true},
+		{"other prefix", pipelineFilter{namePrefix: "staging_"}, job, false},
+		{"prefix is case sensitive", pipelineFilter{namePrefix: "PROD_"}, job, false},
+		{"matching team", pipelineFilter{teamID: "team-2"}, job, true},
+		{"other team", pipelineFilter{teamID: "team-3"}, job, false},
+		{"team filter without team IDs", pipelineFilter{teamID: "team-1"}, noTeams, false},
+		{"prefix and team both match", pipelineFilter{namePrefix: "prod_", teamID: "team-1"}, job, true},
+		{"prefix matches, team does not", pipelineFilter{namePrefix: "prod_", teamID: "team-3"}, job, false},
+		{"deleted excluded by default", pipelineFilter{}, deleted, false},
+		{"deleted included on request", pipelineFilter{includeDeleted: true}, deleted, true},
+		{"deleted included, other filters apply", pipelineFilter{includeDeleted: true, teamID: "team-3"}, deleted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(tt.job); got != tt.expected {
+				t.Errorf("matches() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPipelinesDataSource_Read verifies that grepr_pipelines returns the
+// matching pipelines sorted by name, and only includes deleted pipelines
+// when asked to.
+func TestPipelinesDataSource_Read(t *testing.T) {
+	ctx := context.Background()
+
+	api := &stubJobsAPI{
+		jobs: []client.Job{
+			{Id: "job-1", Name: "prod_b", State: client.JobStateRunning},
+			{Id: "job-2", Name: "prod_a", State: client.JobStateStopped},
+			{Id: "job-3", Name: "prod_old", State: client.JobStateDeleted},
+			{Id: "job-4", Name: "staging", State: client.JobStateRunning},
+		},
+		pageSize: 2,
+	}
+	d := &PipelinesDataSource{client: newStubClient(t, api)}
+
+	tests := []struct {
+		name     string
+		values   map[string]tftypes.Value
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			values:   map[string]tftypes.Value{},
+			expected: []string{"prod_a", "prod_b", "staging"},
+		},
+		{
+			name:     "name prefix",
+			values:   map[string]tftypes.Value{"name_prefix": tftypes.NewValue(tftypes.String, "prod_")},
+			expected: []string{"prod_a", "prod_b"},
+		},
+		{
+			name: "include deleted",
+			values: map[string]tftypes.Value{
+				"name_prefix":     tftypes.NewValue(tftypes.String, "prod_"),
+				"include_deleted": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expected: []string{"prod_a", "prod_b", "prod_old"},
+		},
+		{
+			name:     "deleted state",
+			values:   map[string]tftypes.Value{"state": tftypes.NewValue(tftypes.String, "DELETED")},
+			expected: []string{"prod_old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := PipelinesDataSourceSchema()
+			req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schema, Raw: testObjectValue(ctx, schema.Type(), tt.values)}}
+			resp := &datasource.ReadResponse{State: tfsdk.State{
+				Schema: schema,
+				Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+			}}
+
+			d.Read(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var state PipelinesDataSourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			var summaries []PipelineSummaryModel
+			resp.Diagnostics.Append(state.Pipelines.ElementsAs(ctx, &summaries, false)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("failed to read state: %v", resp.Diagnostics)
+			}
+
+			names := make([]string, 0, len(summaries))
+			for _, s := range summaries {
+				names = append(names, s.Name.ValueString())
+			}
+			if len(names) != len(tt.expected) {
+				t.Fatalf("expected pipelines %v, got %v", tt.expected, names)
+			}
+			for i := range names {
+				if names[i] != tt.expected[i] {
+					t.Errorf("expected pipelines %v, got %v", tt.expected, names)
+					break
+				}
+			}
+		})
+	}
+}
@@ -17,12 +17,16 @@ package pipeline
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
 	"github.com/grepr-ai/terraform-provider-grepr/internal/client/generated"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/providerdata"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -31,17 +35,77 @@ import (
 
 // Compile-time checks that PipelineResource implements required interfaces
 var (
-	_ resource.Resource                = &PipelineResource{}
-	_ resource.ResourceWithConfigure   = &PipelineResource{}
-	_ resource.ResourceWithImportState = &PipelineResource{}
+	_ resource.Resource                   = &PipelineResource{}
+	_ resource.ResourceWithConfigure      = &PipelineResource{}
+	_ resource.ResourceWithImportState    = &PipelineResource{}
+	_ resource.ResourceWithValidateConfig = &PipelineResource{}
+	_ resource.ResourceWithModifyPlan     = &PipelineResource{}
+	_ resource.ResourceWithIdentity       = &PipelineResource{}
 
 	// namePattern enforces pipeline naming rules: lowercase alphanumeric and underscores only
 	namePattern = regexp.MustCompile(`^[a-z0-9_]{1,128}$`)
 )
 
+// IsValidName reports whether name is a valid grepr_pipeline name. Pipelines
+// created outside Terraform may have names that are not.
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Import ID prefixes selecting whether an import ID is looked up as an ID or a name.
+const (
+	importPrefixID   = "id:"
+	importPrefixName = "name:"
+)
+
+// Adoption modes for the adopt_existing attribute.
+const (
+	// adoptAlways adopts any existing pipeline with the same name (the historical behavior).
+	adoptAlways = "always"
+	// adoptNever fails if a pipeline with the same name already exists.
+	adoptNever = "never"
+	// adoptIfTagged adopts an existing pipeline only if it carries the ownership tag.
+	adoptIfTagged = "if_tagged"
+)
+
+// OwnershipTagKey is the tag stamped on every pipeline the provider creates, so
+// that adopt_existing = "if_tagged" can tell the pipelines of this provider
+// configuration apart from pipelines created in the UI, by other tools or by
+// other workspaces. Its value is the provider's ownership_id (see
+// OwnershipTagValue). It is not stored in state.
+const OwnershipTagKey = "terraform_managed"
+
+// defaultOwnershipID is the ownership tag value used when the provider's
+// ownership_id is not set. It is shared by every provider configuration
+// without an ownership_id, so it does not tell their pipelines apart.
+const defaultOwnershipID = "true"
+
+// OwnershipTagValue returns the value of the ownership tag stamped on the
+// pipelines of the provider configuration with the given ownership_id.
+func OwnershipTagValue(ownershipID string) string {
+	if ownershipID == "" {
+		return defaultOwnershipID
+	}
+	return ownershipID
+}
+
 // PipelineResource defines the resource implementation.
 type PipelineResource struct {
 	client *client.Client
+
+	// defaultTags are the provider-level default_tags, merged into every pipeline's tags.
+	defaultTags map[string]string
+
+	// defaultTagsUnknown is true when default_tags is not known during plan.
+	defaultTagsUnknown bool
+
+	// organizationID is the provider-level organization_id. If set, only
+	// pipelines of this organization can be imported.
+	organizationID string
+
+	// ownershipID is the provider-level ownership_id, stamped as the value of
+	// the ownership tag and required of pipelines adopted with "if_tagged".
+	ownershipID string
 }
 
 // NewPipelineResource creates a new pipeline resource.
@@ -59,22 +123,99 @@ func (r *PipelineResource) Schema(ctx context.Context, req resource.SchemaReques
 	resp.Schema = PipelineSchema()
 }
 
+// IdentitySchema returns the resource identity schema.
+func (r *PipelineResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = PipelineIdentitySchema()
+}
+
 // Configure sets up the resource with the provider client.
 func (r *PipelineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*providerdata.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *providerdata.ProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = data.Client
+	r.defaultTags = data.DefaultTags
+	r.defaultTagsUnknown = data.DefaultTagsUnknown
+	r.organizationID = data.OrganizationID
+	r.ownershipID = data.OwnershipID
+}
+
+// ValidateConfig checks that the job graph is defined exactly once, either as
+// job_graph_json or as vertex and edge blocks, and that its structure is valid
+// (see jobgraph.Validate).
+func (r *PipelineResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config PipelineResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values (e.g., dynamic blocks over unknown collections) are validated at apply time
+	if config.JobGraphJSON.IsUnknown() || config.Vertices.IsUnknown() || config.Edges.IsUnknown() {
+		return
+	}
+
+	hasJSON := !config.JobGraphJSON.IsNull()
+	hasBlocks := usesGraphBlocks(config)
+	hasEdges := !config.Edges.IsNull() && len(config.Edges.Elements()) > 0
+
+	switch {
+	case hasJSON && (hasBlocks || hasEdges):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("job_graph_json"),
+			"Conflicting Job Graph Configuration",
+			"Only one of `job_graph_json` or `vertex`/`edge` blocks may be set.",
+		)
+	case !hasJSON && !hasBlocks:
+		resp.Diagnostics.AddError(
+			"Missing Job Graph Configuration",
+			"The pipeline requires a job graph. Set either `job_graph_json` or one or more `vertex` blocks.",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.validateJobGraph(ctx, config, &resp.Diagnostics)
+}
+
+// ModifyPlan computes tags_all from the provider's default_tags and the
+// resource's tags, so that the plan shows the effective set of tags. If either
+// is not known yet, tags_all is planned as unknown.
+func (r *PipelineResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if tags.IsUnknown() || r.defaultTagsUnknown {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	resourceTags, err := r.extractTags(ctx, tags)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("tags"), "Failed to extract tags", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsMapValue(ctx, r.effectiveTags(resourceTags)))...)
 }
 
 // Create creates a new pipeline or adopts an existing one.
@@ -85,6 +226,10 @@ func (r *PipelineResource) Configure(ctx context.Context, req resource.Configure
 //   - Re-run terraform apply after manual creation in the UI
 //   - Recover from partial failures where the resource was created but not tracked
 //
+// Adoption is controlled by adopt_existing: "always" adopts any pipeline with
+// the name, "never" fails with a conflict, and "if_tagged" only adopts
+// pipelines carrying the ownership tag that this provider stamps on create.
+//
 // After adoption, if the plan differs from the existing pipeline's configuration,
 // an update will be performed to reconcile them.
 func (r *PipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -100,7 +245,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	// Check if a pipeline with this name already exists (for adoption)
 	existingJob, err := r.client.GetJobByName(ctx, name)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to check for existing pipeline", err.Error())
+		addJobLookupError(&resp.Diagnostics, "Failed to check for existing pipeline", err)
 		return
 	}
 
@@ -109,6 +254,10 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	var tagsToPreserve map[string]string
 
 	if existingJob != nil {
+		if !r.canAdopt(plan, existingJob, &resp.Diagnostics) {
+			return
+		}
+
 		// Adopt the existing pipeline
 		tflog.Info(ctx, "Adopting existing pipeline", map[string]interface{}{
 			"name": name,
@@ -167,6 +316,18 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 		tagsToPreserve = tags
 
 		newJob, err := r.client.CreateAsyncJob(ctx, *createReq)
+		var conflictErr *client.CreateConflictError
+		if errors.As(err, &conflictErr) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Pipeline Already Exists",
+				fmt.Sprintf("Creating the pipeline failed and a pipeline named %q that this apply did not create now exists (ID: %s). "+
+					"It may have been created concurrently by another workspace or in the UI. "+
+					"Choose a different name or import the pipeline with terraform import. Original error: %s",
+					conflictErr.Name, conflictErr.ID, conflictErr.Err),
+			)
+			return
+		}
 		if err != nil {
 			resp.Diagnostics.AddError("Failed to create pipeline", err.Error())
 			return
@@ -199,6 +360,7 @@ func (r *PipelineResource) Create(ctx context.Context, req resource.CreateReques
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, job)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -209,36 +371,113 @@ func (r *PipelineResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	// A state used with the provider configured for another organization is
+	// refused before the API call, as the pipeline would not be found and
+	// Terraform would plan to create a duplicate in the wrong organization.
+	identityOrgID, diags := identityOrganization(ctx, req.Identity)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if identityOrgID != "" && r.organizationID != "" && identityOrgID != r.organizationID {
+		resp.Diagnostics.AddError(
+			"Pipeline organization mismatch",
+			fmt.Sprintf("Pipeline %s belongs to organization %s according to its identity, but the provider is configured for organization %s. "+
+				"Check that the provider is configured for the organization this state was created with.",
+				state.ID.ValueString(), identityOrgID, r.organizationID),
+		)
+		return
+	}
+
 	id := state.ID.ValueString()
 	if id == "" {
 		// Try to look up by name
 		name := state.Name.ValueString()
 		job, err := r.client.GetJobByName(ctx, name)
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to read pipeline", err.Error())
+			addJobLookupError(&resp.Diagnostics, "Failed to read pipeline", err)
 			return
 		}
 		if job == nil {
-			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.Append(r.checkMissingPipelineOrganization(ctx, name, identityOrgID)...)
+			if !resp.Diagnostics.HasError() {
+				resp.State.RemoveResource(ctx)
+			}
+			return
+		}
+		resp.Diagnostics.Append(checkIdentity(ctx, req.Identity, job)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 		r.updateModelFromJob(ctx, &state, job, nil)
 		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, job)...)
 		return
 	}
 
 	job, err := r.client.GetJob(ctx, id)
 	if err != nil {
 		if apiErr, ok := err.(*client.APIError); ok && apiErr.IsNotFound() {
-			resp.State.RemoveResource(ctx)
+			resp.Diagnostics.Append(r.checkMissingPipelineOrganization(ctx, id, identityOrgID)...)
+			if !resp.Diagnostics.HasError() {
+				resp.State.RemoveResource(ctx)
+			}
 			return
 		}
 		resp.Diagnostics.AddError("Failed to read pipeline", err.Error())
 		return
 	}
 
+	// Refuse to refresh a pipeline from another organization instead of
+	// overwriting the state with it.
+	resp.Diagnostics.Append(checkIdentity(ctx, req.Identity, job)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.updateModelFromJob(ctx, &state, job, nil)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, job)...)
+}
+
+// checkMissingPipelineOrganization is called when the pipeline in state no
+// longer exists. Before it is removed from state, the organization of the
+// provider's credentials is compared with the one recorded in the pipeline's
+// identity: the API reports pipelines of other organizations as not found, so
+// a mismatch means the credentials are wrong rather than the pipeline gone.
+// Without organization_id, the credentials' organization is taken from any
+// pipeline they can see; if there is none, the pipeline is assumed deleted.
+func (r *PipelineResource) checkMissingPipelineOrganization(ctx context.Context, pipeline, identityOrgID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if identityOrgID == "" {
+		return diags
+	}
+
+	orgID := r.organizationID
+	if orgID == "" {
+		for job, err := range r.client.Jobs(ctx, client.ListJobsOptions{}) {
+			if err != nil {
+				diags.AddError(
+					"Failed to read pipeline",
+					fmt.Sprintf("Pipeline %s was not found, and checking the organization of the provider's credentials failed: %s", pipeline, err),
+				)
+				return diags
+			}
+			orgID = job.OrganizationId
+			break
+		}
+	}
+
+	if orgID != "" && orgID != identityOrgID {
+		diags.AddError(
+			"Pipeline organization mismatch",
+			fmt.Sprintf("Pipeline %s was not found, but it belongs to organization %s according to its identity and the provider's credentials are for organization %s. "+
+				"It is kept in state instead of being recreated in the wrong organization. "+
+				"Check that the provider is configured for the organization this state was created with.",
+				pipeline, identityOrgID, orgID),
+		)
+	}
+	return diags
 }
 
 // Update updates the pipeline.
@@ -264,16 +503,30 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	updateReq, err := r.buildUpdateRequest(ctx, plan, currentJob)
+	// Extract tags from plan to preserve in state
+	tags, err := r.extractTags(ctx, plan.Tags)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to build update request", err.Error())
+		resp.Diagnostics.AddError("Failed to extract tags", err.Error())
 		return
 	}
 
-	// Extract tags from plan to preserve in state
-	tags, err := r.extractTags(ctx, plan.Tags)
+	// Skip the API call if the plan only changes provider-only settings or
+	// reformats job_graph_json; the state still takes the configured values.
+	if plan.TeamIDs.Equal(state.TeamIDs) && !r.needsUpdate(ctx, plan, currentJob) {
+		tflog.Debug(ctx, "Pipeline is up to date, skipping update", map[string]interface{}{"id": id})
+		r.updateModelFromJob(ctx, &plan, currentJob, &originalJobData{
+			JobGraphJSON: plan.JobGraphJSON.ValueString(),
+			Tags:         tags,
+			DesiredState: plan.DesiredState.ValueString(),
+		})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, currentJob)...)
+		return
+	}
+
+	updateReq, err := r.buildUpdateRequest(ctx, plan, currentJob)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to extract tags", err.Error())
+		resp.Diagnostics.AddError("Failed to build update request", err.Error())
 		return
 	}
 
@@ -318,6 +571,7 @@ func (r *PipelineResource) Update(ctx context.Context, req resource.UpdateReques
 		DesiredState: plan.DesiredState.ValueString(),
 	})
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, job)...)
 }
 
 // Delete deletes the pipeline.
@@ -353,44 +607,176 @@ func (r *PipelineResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
-// ImportState imports an existing pipeline by ID or name.
+// ImportState imports an existing pipeline.
+//
+// The import ID is either `id:<tsid>`, `name:<pipeline_name>`, or a bare value
+// that is tried as an ID and then as a name. With an identity import, the
+// identity's id is used. The full state is populated from the pipeline, and
+// pipelines from an organization other than the provider's organization_id
+// are refused.
 func (r *PipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idOrName := req.ID
+	importID := req.ID
+	if importID == "" && req.Identity != nil {
+		var identity PipelineIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		importID = importPrefixID + identity.ID.ValueString()
+	}
 
-	// First try to get by ID
-	job, err := r.client.GetJob(ctx, idOrName)
-	if err != nil {
-		if apiErr, ok := err.(*client.APIError); ok && apiErr.IsNotFound() {
-			// Try by name
-			job, err = r.client.GetJobByName(ctx, idOrName)
-			if err != nil {
-				resp.Diagnostics.AddError("Failed to import pipeline", err.Error())
-				return
-			}
-			if job == nil {
-				resp.Diagnostics.AddError(
-					"Pipeline not found",
-					fmt.Sprintf("No pipeline found with ID or name: %s", idOrName),
-				)
-				return
+	job := r.lookupImportJob(ctx, importID, &resp.Diagnostics)
+	if job == nil {
+		return
+	}
+
+	if r.organizationID != "" && job.OrganizationId != r.organizationID {
+		resp.Diagnostics.AddError(
+			"Pipeline organization mismatch",
+			fmt.Sprintf("Pipeline %s belongs to organization %s, but the provider is configured for organization %s.",
+				job.Id, job.OrganizationId, r.organizationID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(checkIdentity(ctx, req.Identity, job)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model := r.modelFromJob(ctx, job)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, job)...)
+}
+
+// lookupImportJob finds the pipeline referenced by an import ID (see
+// ImportState). It returns nil and adds an error diagnostic if there is none.
+func (r *PipelineResource) lookupImportJob(ctx context.Context, importID string, diags *diag.Diagnostics) *client.Job {
+	switch {
+	case strings.HasPrefix(importID, importPrefixID):
+		id := strings.TrimPrefix(importID, importPrefixID)
+		job, err := r.client.GetJob(ctx, id)
+		if err != nil {
+			if apiErr, ok := err.(*client.APIError); ok && apiErr.IsNotFound() {
+				diags.AddError("Pipeline not found", fmt.Sprintf("No pipeline found with ID: %s", id))
+				return nil
 			}
-		} else {
-			resp.Diagnostics.AddError("Failed to import pipeline", err.Error())
-			return
+			diags.AddError("Failed to import pipeline", err.Error())
+			return nil
+		}
+		return job
+
+	case strings.HasPrefix(importID, importPrefixName):
+		name := strings.TrimPrefix(importID, importPrefixName)
+		job, err := r.client.GetJobByName(ctx, name)
+		if err != nil {
+			addJobLookupError(diags, "Failed to import pipeline", err)
+			return nil
+		}
+		if job == nil {
+			diags.AddError("Pipeline not found", fmt.Sprintf("No pipeline found with name: %s", name))
+			return nil
 		}
+		return job
+	}
+
+	// Bare value: first try to get by ID, then by name
+	job, err := r.client.GetJob(ctx, importID)
+	if err == nil {
+		return job
+	}
+	if apiErr, ok := err.(*client.APIError); !ok || !apiErr.IsNotFound() {
+		diags.AddError("Failed to import pipeline", err.Error())
+		return nil
 	}
 
-	// Set the ID for import
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), job.Id)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), job.Name)...)
+	job, err = r.client.GetJobByName(ctx, importID)
+	if err != nil {
+		addJobLookupError(diags, "Failed to import pipeline", err)
+		return nil
+	}
+	if job == nil {
+		diags.AddError(
+			"Pipeline not found",
+			fmt.Sprintf("No pipeline found with ID or name: %s. Use %s<id> or %s<name> to import unambiguously.",
+				importID, importPrefixID, importPrefixName),
+		)
+		return nil
+	}
+	return job
+}
+
+// modelFromJob builds the complete state of an existing pipeline, the state
+// that importing it produces. Provider-only settings take their schema
+// defaults, and the job graph is reported as job_graph_json.
+func (r *PipelineResource) modelFromJob(ctx context.Context, job *client.Job) PipelineResourceModel {
+	model := PipelineResourceModel{
+		JobGraphJSON:    NewJobGraphJSONNull(),
+		Vertices:        types.ListNull(vertexObjectType),
+		Edges:           types.ListNull(edgeObjectType),
+		Tags:            types.MapNull(types.StringType),
+		WaitForState:    types.BoolValue(true),
+		StateTimeout:    types.Int64Value(600),
+		RollbackEnabled: types.BoolValue(false),
+		AdoptExisting:   types.StringValue(adoptAlways),
+	}
+	r.updateModelFromJob(ctx, &model, job, nil)
+	return model
+}
+
+// canAdopt checks adopt_existing against an existing pipeline with the planned
+// name and adds a conflict diagnostic if it must not be adopted.
+func (r *PipelineResource) canAdopt(plan PipelineResourceModel, existingJob *client.Job, diags *diag.Diagnostics) bool {
+	switch plan.AdoptExisting.ValueString() {
+	case adoptNever:
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Pipeline Already Exists",
+			fmt.Sprintf("A pipeline named %q already exists (ID: %s) and adopt_existing is %q. "+
+				"Choose a different name, import the pipeline with terraform import, or change adopt_existing.",
+				existingJob.Name, existingJob.Id, adoptNever),
+		)
+		return false
+	case adoptIfTagged:
+		owner := OwnershipTagValue(r.ownershipID)
+		if existingJob.Tags[OwnershipTagKey] != owner {
+			diags.AddAttributeError(
+				path.Root("name"),
+				"Pipeline Already Exists",
+				fmt.Sprintf("A pipeline named %q already exists (ID: %s) but does not carry the %s=%s ownership tag, "+
+					"so adopt_existing = %q will not adopt it. It may be managed by another workspace. "+
+					"Choose a different name, import the pipeline with terraform import, or change adopt_existing.",
+					existingJob.Name, existingJob.Id, OwnershipTagKey, owner, adoptIfTagged),
+			)
+			return false
+		}
+	}
+	return true
+}
+
+// addJobLookupError reports a failed GetJobByName call. When several live
+// pipelines share the name, a dedicated diagnostic lists their IDs so the user
+// can import the right one by ID instead of Terraform picking one arbitrarily.
+func addJobLookupError(diags *diag.Diagnostics, summary string, err error) {
+	var ambiguousErr *client.AmbiguousNameError
+	if errors.As(err, &ambiguousErr) {
+		diags.AddError(
+			"Ambiguous Pipeline Name",
+			fmt.Sprintf("Found %d pipelines named %q (IDs: %s). Terraform cannot tell which one to manage. "+
+				"Rename or delete the duplicates, or import the intended pipeline by ID.",
+				len(ambiguousErr.IDs), ambiguousErr.Name, strings.Join(ambiguousErr.IDs, ", ")),
+		)
+		return
+	}
+	diags.AddError(summary, err.Error())
 }
 
 // buildCreateRequest builds a CreateJobRequest from the plan.
 // Returns the request and the extracted tags map for state preservation.
 func (r *PipelineResource) buildCreateRequest(ctx context.Context, plan PipelineResourceModel) (*client.CreateJobRequest, map[string]string, error) {
-	jobGraph, err := r.parseJobGraph(plan.JobGraphJSON.ValueString())
+	jobGraph, err := r.planJobGraph(ctx, plan)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse job_graph_json: %w", err)
+		return nil, nil, err
 	}
 
 	tags, err := r.extractTags(ctx, plan.Tags)
@@ -408,16 +794,21 @@ func (r *PipelineResource) buildCreateRequest(ctx context.Context, plan Pipeline
 		Execution:  generated.CreateJobExecutionASYNCHRONOUS,
 		Processing: generated.CreateJobProcessingSTREAMING,
 		JobGraph:   *jobGraph,
-		Tags:       mapToCreateJobTags(tags),
+		Tags:       mapToJobTags(r.withOwnershipTag(r.effectiveTags(tags))),
 		TeamIds:    teamIDs,
 	}, tags, nil
 }
 
 // buildUpdateRequest builds an UpdateJobRequest from the plan and current job.
 func (r *PipelineResource) buildUpdateRequest(ctx context.Context, plan PipelineResourceModel, currentJob *client.Job) (*client.UpdateJobRequest, error) {
-	jobGraph, err := r.parseJobGraph(plan.JobGraphJSON.ValueString())
+	jobGraph, err := r.planJobGraph(ctx, plan)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse job_graph_json: %w", err)
+		return nil, err
+	}
+
+	tags, err := r.extractTags(ctx, plan.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract tags: %w", err)
 	}
 
 	teamIDs, err := r.extractTeamIDs(ctx, plan.TeamIDs)
@@ -425,10 +816,13 @@ func (r *PipelineResource) buildUpdateRequest(ctx context.Context, plan Pipeline
 		return nil, fmt.Errorf("failed to extract team_ids: %w", err)
 	}
 
+	// Tags are always sent so that removing a tag from the configuration removes it
+	// from the pipeline. The ownership tag is kept since Terraform manages the pipeline.
 	return &client.UpdateJobRequest{
 		FromVersion:  currentJob.Version,
 		DesiredState: generated.UpdateJobDesiredState(plan.DesiredState.ValueString()),
 		JobGraph:     *jobGraph,
+		Tags:         mapToJobTags(r.withOwnershipTag(r.effectiveTags(tags))),
 		TeamIds:      teamIDs,
 	}, nil
 }
@@ -477,38 +871,96 @@ func (r *PipelineResource) needsUpdate(ctx context.Context, plan PipelineResourc
 		return true
 	}
 
-	// Check job graph - compare JSON
-	planGraph, err := r.parseJobGraph(plan.JobGraphJSON.ValueString())
+	// Check job graph - compare semantically so server-added defaults don't force an update
+	planGraph, err := r.planJobGraph(ctx, plan)
 	if err != nil {
 		return true
 	}
 
-	planGraphJSON, err := json.Marshal(planGraph)
+	equivalent, err := jobGraphsEquivalent(planGraph, &currentJob.JobGraph)
 	if err != nil {
-		tflog.Error(ctx, "Failed to marshal plan job graph", map[string]interface{}{"error": err.Error()})
+		tflog.Error(ctx, "Failed to compare job graphs", map[string]interface{}{"error": err.Error()})
 		return true
 	}
 
-	currentGraphJSON, err := json.Marshal(currentJob.JobGraph)
-	if err != nil {
-		tflog.Error(ctx, "Failed to marshal current job graph", map[string]interface{}{"error": err.Error()})
+	if !equivalent {
 		return true
 	}
 
-	if string(planGraphJSON) != string(currentGraphJSON) {
+	// Check tags (including default tags), ignoring the provider-managed ownership tag
+	planTags, err := r.extractTags(ctx, plan.Tags)
+	if err != nil {
+		return true
+	}
+	if !tagsEqual(r.effectiveTags(planTags), withoutProviderTags(readJobTagsToMap(currentJob.Tags))) {
 		return true
 	}
 
 	return false
 }
 
+// effectiveTags returns the provider's default tags overlaid with the
+// resource's tags. This is the set of tags sent to the API and shown in tags_all.
+func (r *PipelineResource) effectiveTags(tags map[string]string) map[string]string {
+	merged := make(map[string]string, len(r.defaultTags)+len(tags))
+	for k, v := range r.defaultTags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// resourceTagsFromServer derives the resource's own tags from the server's tags
+// by removing tags that only come from the provider's default_tags.
+//
+// A server tag equal to a default tag is attributed to the defaults, unless the
+// resource's prior tags also set it, so that tags explicitly duplicated in the
+// resource configuration are not reported as drift.
+func (r *PipelineResource) resourceTagsFromServer(serverTags, priorTags map[string]string) map[string]string {
+	tags := make(map[string]string, len(serverTags))
+	for k, v := range serverTags {
+		if dv, ok := r.defaultTags[k]; ok && dv == v {
+			if _, inPrior := priorTags[k]; !inPrior {
+				continue
+			}
+		}
+		tags[k] = v
+	}
+	return tags
+}
+
+// tagsMapValue converts tags into a Terraform map, using null for no tags.
+func tagsMapValue(ctx context.Context, tags map[string]string) types.Map {
+	if len(tags) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	value, _ := types.MapValueFrom(ctx, types.StringType, tags)
+	return value
+}
+
+// tagsEqual returns true if both tag maps contain the same keys and values.
+// A nil map is equal to an empty map.
+func tagsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
 // originalJobData holds values from the original Terraform plan that should be
 // preserved in state rather than using the API response values.
 //
 // This is important because:
 //   - job_graph_json: The API may add default fields or reorder JSON keys, causing
 //     spurious diffs on subsequent plans
-//   - tags: The API may add system tags that the user didn't specify
+//   - tags: The ownership tag is sent to the API but never stored in state
 //   - desired_state: We want to track what the user requested, not the current state
 type originalJobData struct {
 	JobGraphJSON string
@@ -545,32 +997,29 @@ func (r *PipelineResource) updateModelFromJob(ctx context.Context, model *Pipeli
 	model.PipelineHealth = types.StringNull()
 	model.PipelineMessage = types.StringNull()
 
-	// Use the original request's job graph JSON if provided, otherwise use the API response
-	// This avoids inconsistencies from server-added default fields and JSON field ordering
+	// Use the original request's job graph JSON if provided. This avoids inconsistencies from
+	// server-added default fields and JSON field ordering. When the graph is defined with
+	// vertex blocks, job_graph_json stays null. On Read (no original data), the server's
+	// graph is reconciled against state so that out-of-band changes are detected.
 	if originalData != nil && originalData.JobGraphJSON != "" {
-		model.JobGraphJSON = types.StringValue(originalData.JobGraphJSON)
-	} else if model.JobGraphJSON.IsUnknown() || model.JobGraphJSON.IsNull() {
-		jobGraphJSON, err := json.Marshal(job.JobGraph)
-		if err == nil {
-			model.JobGraphJSON = types.StringValue(string(jobGraphJSON))
-		}
+		model.JobGraphJSON = NewJobGraphJSONValue(originalData.JobGraphJSON)
+	} else if originalData != nil && usesGraphBlocks(*model) {
+		model.JobGraphJSON = NewJobGraphJSONNull()
+	} else {
+		r.refreshJobGraph(ctx, model, &job.JobGraph)
 	}
 
-	// Use the original request's tags if provided, otherwise use the API response
-	// This avoids inconsistencies from server-added system tags
+	// Use the original request's tags if provided, otherwise use the API response.
+	// On Read, the server's tags always replace the state so that tag changes made
+	// outside Terraform show up as drift. The ownership tag is never stored in state.
 	if originalData != nil {
-		if len(originalData.Tags) > 0 {
-			model.Tags, _ = types.MapValueFrom(ctx, types.StringType, originalData.Tags)
-		} else {
-			model.Tags = types.MapNull(types.StringType)
-		}
-	} else if model.Tags.IsUnknown() || model.Tags.IsNull() {
-		tags := readJobTagsToMap(job.Tags)
-		if len(tags) > 0 {
-			model.Tags, _ = types.MapValueFrom(ctx, types.StringType, tags)
-		} else {
-			model.Tags = types.MapNull(types.StringType)
-		}
+		model.Tags = tagsMapValue(ctx, originalData.Tags)
+		model.TagsAll = tagsMapValue(ctx, r.effectiveTags(originalData.Tags))
+	} else {
+		serverTags := withoutProviderTags(readJobTagsToMap(job.Tags))
+		priorTags, _ := r.extractTags(ctx, model.Tags)
+		model.Tags = tagsMapValue(ctx, r.resourceTagsFromServer(serverTags, priorTags))
+		model.TagsAll = tagsMapValue(ctx, serverTags)
 	}
 
 	// Convert team IDs
@@ -581,8 +1030,32 @@ func (r *PipelineResource) updateModelFromJob(ctx context.Context, model *Pipeli
 	}
 }
 
-// mapToCreateJobTags converts a map[string]string to *map[string]string for CreateJob
-func mapToCreateJobTags(m map[string]string) *map[string]string {
+// withOwnershipTag returns a copy of tags with the ownership tag added.
+func (r *PipelineResource) withOwnershipTag(tags map[string]string) map[string]string {
+	result := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		result[k] = v
+	}
+	result[OwnershipTagKey] = OwnershipTagValue(r.ownershipID)
+	return result
+}
+
+// withoutProviderTags returns a copy of tags without the ownership tag and the
+// client's idempotency key tag, which are managed by the provider and never
+// stored in state.
+func withoutProviderTags(tags map[string]string) map[string]string {
+	result := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if k == OwnershipTagKey || k == client.IdempotencyKeyTag {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// mapToJobTags converts a map[string]string to *map[string]string for CreateJob and UpdateJob
+func mapToJobTags(m map[string]string) *map[string]string {
 	return &m
 }
 
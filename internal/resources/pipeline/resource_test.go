@@ -0,0 +1,454 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client/generated"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestCanAdopt verifies that adopt_existing controls whether Create adopts an
+// existing pipeline with the same name, and that "if_tagged" only adopts
+// pipelines carrying this provider's ownership ID.
+func TestCanAdopt(t *testing.T) {
+	tagged := &client.Job{Id: "job-1", Name: "p", Tags: map[string]string{OwnershipTagKey: "workspace-a"}}
+	foreign := &client.Job{Id: "job-2", Name: "p", Tags: map[string]string{OwnershipTagKey: "workspace-b"}}
+	defaultTagged := &client.Job{Id: "job-3", Name: "p", Tags: map[string]string{OwnershipTagKey: "true"}}
+	untagged := &client.Job{Id: "job-4", Name: "p", Tags: map[string]string{"env": "prod"}}
+
+	tests := []struct {
+		name        string
+		mode        string
+		ownershipID string
+		job         *client.Job
+		expected    bool
+	}{
+		{"always adopts untagged", adoptAlways, "workspace-a", untagged, true},
+		{"always adopts foreign", adoptAlways, "workspace-a", foreign, true},
+		{"never rejects tagged", adoptNever, "workspace-a", tagged, false},
+		{"never rejects untagged", adoptNever, "workspace-a", untagged, false},
+		{"if_tagged adopts own", adoptIfTagged, "workspace-a", tagged, true},
+		{"if_tagged rejects foreign", adoptIfTagged, "workspace-a", foreign, false},
+		{"if_tagged rejects default owner", adoptIfTagged, "workspace-a", defaultTagged, false},
+		{"if_tagged rejects untagged", adoptIfTagged, "workspace-a", untagged, false},
+		{"if_tagged without ownership ID adopts default owner", adoptIfTagged, "", defaultTagged, true},
+		{"if_tagged without ownership ID rejects foreign", adoptIfTagged, "", foreign, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			plan := PipelineResourceModel{AdoptExisting: types.StringValue(tt.mode)}
+
+			r := &PipelineResource{ownershipID: tt.ownershipID}
+			if got := r.canAdopt(plan, tt.job, &diags); got != tt.expected {
+				t.Errorf("canAdopt() = %v, expected %v", got, tt.expected)
+			}
+			if diags.HasError() == tt.expected {
+				t.Errorf("expected error diagnostic: %v, got %v", !tt.expected, diags)
+			}
+		})
+	}
+}
+
+// TestOwnershipTag verifies that the ownership tag carrying the ownership ID is
+// added to create requests without modifying the configured tags, and that it
+// and the idempotency key tag are stripped when reading tags back.
+func TestOwnershipTag(t *testing.T) {
+	tags := map[string]string{"env": "prod"}
+
+	r := &PipelineResource{ownershipID: "workspace-a"}
+	stamped := r.withOwnershipTag(tags)
+	if stamped[OwnershipTagKey] != "workspace-a" || stamped["env"] != "prod" {
+		t.Errorf("expected ownership tag and env tag, got %v", stamped)
+	}
+	if _, ok := tags[OwnershipTagKey]; ok {
+		t.Errorf("withOwnershipTag() must not modify its input")
+	}
+
+	stamped[client.IdempotencyKeyTag] = "key"
+	stripped := withoutProviderTags(stamped)
+	if len(stripped) != 1 || stripped["env"] != "prod" {
+		t.Errorf("expected only the env tag, got %v", stripped)
+	}
+
+	if got := (&PipelineResource{}).withOwnershipTag(nil)[OwnershipTagKey]; got != "true" {
+		t.Errorf("expected the default ownership tag value true, got %q", got)
+	}
+}
+
+// TestBuildUpdateRequest_Tags verifies that configured tags are sent on update,
+// together with the ownership tag, and that removing all tags clears them.
+func TestBuildUpdateRequest_Tags(t *testing.T) {
+	ctx := context.Background()
+	currentJob := &client.Job{Id: "job-1", Version: 3}
+
+	tests := []struct {
+		name     string
+		tags     types.Map
+		expected map[string]string
+	}{
+		{
+			name:     "configured tags",
+			tags:     types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("prod")}),
+			expected: map[string]string{"env": "prod", OwnershipTagKey: "workspace-a"},
+		},
+		{
+			name:     "no tags",
+			tags:     types.MapNull(types.StringType),
+			expected: map[string]string{OwnershipTagKey: "workspace-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PipelineResourceModel{
+				JobGraphJSON: NewJobGraphJSONValue(`{"vertices":[],"edges":[]}`),
+				Vertices:     types.ListNull(vertexObjectType),
+				Edges:        types.ListNull(edgeObjectType),
+				DesiredState: types.StringValue("RUNNING"),
+				Tags:         tt.tags,
+				TeamIDs:      types.SetNull(types.StringType),
+			}
+
+			r := &PipelineResource{ownershipID: "workspace-a"}
+			req, err := r.buildUpdateRequest(ctx, plan, currentJob)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if req.FromVersion != 3 {
+				t.Errorf("expected fromVersion 3, got %d", req.FromVersion)
+			}
+			if req.Tags == nil || !tagsEqual(*req.Tags, tt.expected) {
+				t.Errorf("expected tags %v, got %v", tt.expected, req.Tags)
+			}
+		})
+	}
+}
+
+// TestTagsEqual verifies tag map comparison, treating nil and empty maps as equal.
+func TestTagsEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     map[string]string
+		expected bool
+	}{
+		{"nil and empty", nil, map[string]string{}, true},
+		{"same", map[string]string{"a": "1"}, map[string]string{"a": "1"}, true},
+		{"different value", map[string]string{"a": "1"}, map[string]string{"a": "2"}, false},
+		{"extra key", map[string]string{"a": "1"}, map[string]string{"a": "1", "b": "2"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tagsEqual(tt.a, tt.b); got != tt.expected {
+				t.Errorf("tagsEqual() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDefaultTags verifies that default tags are merged into the effective tags
+// and removed again when deriving the resource's own tags from the server.
+func TestDefaultTags(t *testing.T) {
+	r := &PipelineResource{defaultTags: map[string]string{"owner": "platform", "cost_center": "42"}}
+
+	effective := r.effectiveTags(map[string]string{"env": "prod", "owner": "data"})
+	expected := map[string]string{"env": "prod", "owner": "data", "cost_center": "42"}
+	if !tagsEqual(effective, expected) {
+		t.Errorf("expected effective tags %v, got %v", expected, effective)
+	}
+
+	tests := []struct {
+		name     string
+		server   map[string]string
+		prior    map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "default tags are not resource tags",
+			server:   map[string]string{"env": "prod", "owner": "platform", "cost_center": "42"},
+			prior:    map[string]string{"env": "prod"},
+			expected: map[string]string{"env": "prod"},
+		},
+		{
+			name:     "overridden default is a resource tag",
+			server:   map[string]string{"owner": "data", "cost_center": "42"},
+			prior:    map[string]string{"owner": "data"},
+			expected: map[string]string{"owner": "data"},
+		},
+		{
+			name:     "duplicated default in prior tags is kept",
+			server:   map[string]string{"owner": "platform", "cost_center": "42"},
+			prior:    map[string]string{"owner": "platform"},
+			expected: map[string]string{"owner": "platform"},
+		},
+		{
+			name:     "tag added outside Terraform is drift",
+			server:   map[string]string{"owner": "platform", "cost_center": "42", "extra": "x"},
+			prior:    nil,
+			expected: map[string]string{"extra": "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.resourceTagsFromServer(tt.server, tt.prior); !tagsEqual(got, tt.expected) {
+				t.Errorf("expected resource tags %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestModifyPlan_TagsAll verifies that tags_all is planned as the merged tags
+// when the tags and default tags are known, and as unknown otherwise.
+func TestModifyPlan_TagsAll(t *testing.T) {
+	ctx := context.Background()
+	tagsType := tftypes.Map{ElementType: tftypes.String}
+	knownTags := tftypes.NewValue(tagsType, map[string]tftypes.Value{"env": tftypes.NewValue(tftypes.String, "prod")})
+
+	tests := []struct {
+		name               string
+		tags               tftypes.Value
+		defaultTagsUnknown bool
+		expected           map[string]string
+	}{
+		{"known", knownTags, false, map[string]string{"env": "prod", "owner": "platform"}},
+		{"unknown tags", tftypes.NewValue(tagsType, tftypes.UnknownValue), false, nil},
+		{"unknown default tags", knownTags, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := PipelineSchema()
+			plan := tfsdk.Plan{Schema: schema, Raw: testObjectValue(ctx, schema.Type(), map[string]tftypes.Value{"tags": tt.tags})}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+
+			r := &PipelineResource{defaultTags: map[string]string{"owner": "platform"}, defaultTagsUnknown: tt.defaultTagsUnknown}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			var tagsAll types.Map
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)...)
+			if tt.expected == nil {
+				if !tagsAll.IsUnknown() {
+					t.Errorf("expected unknown tags_all, got %v", tagsAll)
+				}
+				return
+			}
+			var got map[string]string
+			resp.Diagnostics.Append(tagsAll.ElementsAs(ctx, &got, false)...)
+			if !tagsEqual(got, tt.expected) {
+				t.Errorf("expected tags_all %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestModelFromJob verifies that imported and listed pipelines get a complete
+// state: schema defaults for provider-only settings, the job graph as
+// job_graph_json, and tags without default or ownership tags.
+func TestModelFromJob(t *testing.T) {
+	job := &client.Job{
+		Id:      "job-1",
+		Name:    "prod_pipeline",
+		Version: 3,
+		State:   client.JobStateRunning,
+		Tags: map[string]string{
+			"env":           "prod",
+			"owner":         "platform",
+			OwnershipTagKey: "true",
+		},
+	}
+
+	r := &PipelineResource{defaultTags: map[string]string{"owner": "platform"}}
+	model := r.modelFromJob(context.Background(), job)
+
+	if model.ID.ValueString() != "job-1" || model.Name.ValueString() != "prod_pipeline" {
+		t.Errorf("expected id job-1 and name prod_pipeline, got %s and %s", model.ID, model.Name)
+	}
+	if !model.WaitForState.ValueBool() || model.StateTimeout.ValueInt64() != 600 ||
+		model.RollbackEnabled.ValueBool() || model.AdoptExisting.ValueString() != adoptAlways {
+		t.Errorf("expected schema defaults for provider-only settings, got %+v", model)
+	}
+	if model.JobGraphJSON.IsNull() || !model.Vertices.IsNull() || !model.Edges.IsNull() {
+		t.Errorf("expected the job graph as job_graph_json only")
+	}
+
+	var tags, tagsAll map[string]string
+	model.Tags.ElementsAs(context.Background(), &tags, false)
+	model.TagsAll.ElementsAs(context.Background(), &tagsAll, false)
+	if len(tags) != 1 || tags["env"] != "prod" {
+		t.Errorf("expected tags {env: prod}, got %v", tags)
+	}
+	if len(tagsAll) != 2 || tagsAll["owner"] != "platform" {
+		t.Errorf("expected tags_all with env and owner, got %v", tagsAll)
+	}
+}
+
+// TestNeedsUpdate verifies that a job_graph_json that was only reformatted
+// does not update the pipeline, while real changes do.
+func TestNeedsUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	const serverGraph = `{"vertices":[{"type":"grok-parser","name":"parser","grokParsingRules":["a"],"enabled":true}],"edges":[]}`
+	var jobGraph client.JobGraph
+	if err := json.Unmarshal([]byte(serverGraph), &jobGraph); err != nil {
+		t.Fatalf("failed to decode job graph: %v", err)
+	}
+	currentJob := &client.Job{
+		Id:           "job-1",
+		DesiredState: generated.ReadJobDesiredStateRUNNING,
+		JobGraph:     jobGraph,
+	}
+
+	tests := []struct {
+		name         string
+		graphJSON    string
+		desiredState string
+		expected     bool
+	}{
+		{
+			name:         "reformatted job graph",
+			graphJSON:    "{\n  \"edges\": [],\n  \"vertices\": [{\"name\": \"parser\", \"grokParsingRules\": [\"a\"], \"type\": \"grok-parser\"}]\n}",
+			desiredState: "RUNNING",
+			expected:     false,
+		},
+		{
+			name:         "changed job graph",
+			graphJSON:    `{"vertices":[{"type":"grok-parser","name":"parser","grokParsingRules":["b"]}],"edges":[]}`,
+			desiredState: "RUNNING",
+			expected:     true,
+		},
+		{
+			name:         "changed desired state",
+			graphJSON:    `{"vertices":[{"type":"grok-parser","name":"parser","grokParsingRules":["a"]}],"edges":[]}`,
+			desiredState: "STOPPED",
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PipelineResourceModel{
+				JobGraphJSON: NewJobGraphJSONValue(tt.graphJSON),
+				Vertices:     types.ListNull(vertexObjectType),
+				Edges:        types.ListNull(edgeObjectType),
+				DesiredState: types.StringValue(tt.desiredState),
+				Tags:         types.MapNull(types.StringType),
+			}
+
+			r := &PipelineResource{}
+			if got := r.needsUpdate(ctx, plan, currentJob); got != tt.expected {
+				t.Errorf("needsUpdate() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRead_OrganizationMismatch verifies that a pipeline the API reports as
+// not found is only removed from state if the provider's credentials are for
+// the organization recorded in its identity.
+func TestRead_OrganizationMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		identityOrgID  string
+		organizationID string
+		visibleJobs    []client.Job
+		expectError    bool
+		expectRequests bool
+	}{
+		{
+			name:           "configured organization differs",
+			identityOrgID:  "org-1",
+			organizationID: "org-2",
+			expectError:    true,
+		},
+		{
+			name:           "credentials see another organization",
+			identityOrgID:  "org-1",
+			visibleJobs:    []client.Job{{Id: "job-9", Name: "other", OrganizationId: "org-2"}},
+			expectError:    true,
+			expectRequests: true,
+		},
+		{
+			name:           "configured organization matches",
+			identityOrgID:  "org-1",
+			organizationID: "org-1",
+			expectRequests: true,
+		},
+		{
+			name:           "credentials see the same organization",
+			identityOrgID:  "org-1",
+			visibleJobs:    []client.Job{{Id: "job-9", Name: "other", OrganizationId: "org-1"}},
+			expectRequests: true,
+		},
+		{
+			name:           "credentials see no pipelines",
+			identityOrgID:  "org-1",
+			expectRequests: true,
+		},
+		{
+			name:           "no identity",
+			organizationID: "org-2",
+			expectRequests: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			api := &stubJobsAPI{jobs: tt.visibleJobs}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				api.serveHTTP(w, r)
+			}))
+			defer server.Close()
+			r := &PipelineResource{
+				client:         client.NewClient(client.Config{Host: server.URL, AccessToken: "test-token"}),
+				organizationID: tt.organizationID,
+			}
+
+			schema := PipelineSchema()
+			state := tfsdk.State{Schema: schema, Raw: testObjectValue(ctx, schema.Type(), map[string]tftypes.Value{
+				"id":   tftypes.NewValue(tftypes.String, "job-1"),
+				"name": tftypes.NewValue(tftypes.String, "prod_pipeline"),
+			})}
+			identity := newTestIdentity(ctx)
+			if tt.identityOrgID != "" {
+				model := PipelineIdentityModel{OrganizationID: types.StringValue(tt.identityOrgID), ID: types.StringValue("job-1")}
+				if diags := identity.Set(ctx, model); diags.HasError() {
+					t.Fatalf("failed to set identity: %v", diags)
+				}
+			}
+
+			resp := &resource.ReadResponse{State: state, Identity: identity}
+			r.Read(ctx, resource.ReadRequest{State: state, Identity: identity}, resp)
+
+			if resp.Diagnostics.HasError() != tt.expectError {
+				t.Errorf("expected error: %v, got %v", tt.expectError, resp.Diagnostics)
+			}
+			if removed := resp.State.Raw.IsNull(); removed == tt.expectError {
+				t.Errorf("expected the pipeline to be removed: %v, got %v", !tt.expectError, removed)
+			}
+			if (requests > 0) != tt.expectRequests {
+				t.Errorf("expected API requests: %v, got %d", tt.expectRequests, requests)
+			}
+		})
+	}
+}
@@ -1,5 +1,5 @@
-// Package pipeline provides the Terraform resource implementation for Grepr pipelines.
-// It defines the schema, data model, and plan modifiers for the grepr_pipeline resource.
+// Package pipeline provides the Terraform resource and data source implementations for Grepr pipelines.
+// It defines the schema, data model, and plan modifiers for the grepr_pipeline resource and data source.
 package pipeline
 
 import (
@@ -24,17 +24,21 @@ import (
 // 3. Pipeline status: Nested health and status information
 type PipelineResourceModel struct {
 	// Configuration attributes
-	Name            types.String `tfsdk:"name"`
-	JobGraphJSON    types.String `tfsdk:"job_graph_json"`
-	DesiredState    types.String `tfsdk:"desired_state"`
-	TeamIDs         types.Set    `tfsdk:"team_ids"`
-	Tags            types.Map    `tfsdk:"tags"`
-	WaitForState    types.Bool   `tfsdk:"wait_for_state"`
-	StateTimeout    types.Int64  `tfsdk:"state_timeout"`
-	RollbackEnabled types.Bool   `tfsdk:"rollback_enabled"`
+	Name            types.String      `tfsdk:"name"`
+	JobGraphJSON    JobGraphJSONValue `tfsdk:"job_graph_json"`
+	Vertices        types.List        `tfsdk:"vertex"`
+	Edges           types.List        `tfsdk:"edge"`
+	DesiredState    types.String      `tfsdk:"desired_state"`
+	TeamIDs         types.Set         `tfsdk:"team_ids"`
+	Tags            types.Map         `tfsdk:"tags"`
+	WaitForState    types.Bool        `tfsdk:"wait_for_state"`
+	StateTimeout    types.Int64       `tfsdk:"state_timeout"`
+	RollbackEnabled types.Bool        `tfsdk:"rollback_enabled"`
+	AdoptExisting   types.String      `tfsdk:"adopt_existing"`
 
 	// Computed attributes
 	ID             types.String `tfsdk:"id"`
+	TagsAll        types.Map    `tfsdk:"tags_all"`
 	Version        types.Int64  `tfsdk:"version"`
 	State          types.String `tfsdk:"state"`
 	OrganizationID types.String `tfsdk:"organization_id"`
@@ -46,12 +50,32 @@ type PipelineResourceModel struct {
 	PipelineMessage types.String `tfsdk:"pipeline_message"`
 }
 
+// VertexModel describes a single `vertex` block of the job graph.
+//
+// Common vertex fields have dedicated attributes so that typos are caught by
+// Terraform; any other field can be supplied through properties_json.
+type VertexModel struct {
+	Type             types.String `tfsdk:"type"`
+	Name             types.String `tfsdk:"name"`
+	IntegrationID    types.String `tfsdk:"integration_id"`
+	GrokParsingRules types.List   `tfsdk:"grok_parsing_rules"`
+	DatasetID        types.String `tfsdk:"dataset_id"`
+	PropertiesJSON   types.String `tfsdk:"properties_json"`
+}
+
+// EdgeModel describes a single `edge` block connecting two vertices by name.
+type EdgeModel struct {
+	From types.String `tfsdk:"from"`
+	To   types.String `tfsdk:"to"`
+}
+
 // PipelineSchema returns the complete Terraform schema definition for the grepr_pipeline resource.
 //
 // The schema defines:
-// - Required attributes: name, job_graph_json
-// - Optional attributes: desired_state, team_ids, tags, wait_for_state, state_timeout, rollback_enabled
-// - Computed attributes: id, version, state, organization_id, created_at, updated_at, pipeline_health, pipeline_message
+// - Required attributes: name, and exactly one of job_graph_json or vertex/edge blocks
+// - Optional attributes: desired_state, team_ids, tags, wait_for_state, state_timeout, rollback_enabled, adopt_existing
+// - Blocks: vertex, edge (a structured alternative to job_graph_json)
+// - Computed attributes: id, version, state, organization_id, created_at, updated_at, tags_all, pipeline_health, pipeline_message
 //
 // Plan modifiers are used to:
 // - UseStateForUnknown: Preserve values that won't change (id, organization_id, created_at)
@@ -73,8 +97,9 @@ func PipelineSchema() schema.Schema {
 				},
 			},
 			"job_graph_json": schema.StringAttribute{
-				MarkdownDescription: "The job graph as a JSON string. Use `jsonencode()` to convert a Terraform object to JSON.",
-				Required:            true,
+				MarkdownDescription: "The job graph as a JSON string. Use `jsonencode()` to convert a Terraform object to JSON. Conflicts with `vertex` and `edge` blocks. Graphs are compared semantically against the server: fields it fills in with defaults and the order in which it returns keys, vertices or edges do not cause diffs. Reformatting the JSON in the configuration is shown as an in-place change, but applying it only updates the state and does not update the pipeline.",
+				Optional:            true,
+				CustomType:          JobGraphJSONType{},
 			},
 
 			// Optional configuration
@@ -93,7 +118,7 @@ func PipelineSchema() schema.Schema {
 				ElementType:         types.StringType,
 			},
 			"tags": schema.MapAttribute{
-				MarkdownDescription: "Custom tags for the pipeline.",
+				MarkdownDescription: "Custom tags for the pipeline. Merged with the provider's `default_tags`; tags set here take precedence.",
 				Optional:            true,
 				ElementType:         types.StringType,
 				PlanModifiers: []planmodifier.Map{
@@ -118,6 +143,17 @@ func PipelineSchema() schema.Schema {
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"adopt_existing": schema.StringAttribute{
+				MarkdownDescription: "What to do on create when a pipeline with the same name already exists. " +
+					"`always` adopts it, `never` fails with a conflict, and `if_tagged` adopts it only if its `" + OwnershipTagKey + "` tag, " +
+					"which this provider adds to every pipeline it creates, matches the provider's `ownership_id`. Defaults to `always`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(adoptAlways),
+				Validators: []validator.String{
+					stringvalidator.OneOf(adoptAlways, adoptNever, adoptIfTagged),
+				},
+			},
 
 			// Computed attributes (read-only)
 			"id": schema.StringAttribute{
@@ -135,6 +171,11 @@ func PipelineSchema() schema.Schema {
 				MarkdownDescription: "The actual current state of the pipeline.",
 				Computed:            true,
 			},
+			"tags_all": schema.MapAttribute{
+				MarkdownDescription: "All tags of the pipeline, including those inherited from the provider's `default_tags`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
 			"organization_id": schema.StringAttribute{
 				MarkdownDescription: "The organization ID that owns this pipeline.",
 				Computed:            true,
@@ -162,5 +203,55 @@ func PipelineSchema() schema.Schema {
 				Computed:            true,
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"vertex": schema.ListNestedBlock{
+				MarkdownDescription: "A vertex (source, operation, or sink) of the job graph. Conflicts with `job_graph_json`.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The vertex type (e.g., `datadog-log-agent-source`, `grok-parser`, `logs-iceberg-table-sink`).",
+							Required:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The vertex name, referenced by `edge` blocks. Must be unique within the pipeline.",
+							Required:            true,
+						},
+						"integration_id": schema.StringAttribute{
+							MarkdownDescription: "The integration ID for source vertices such as `datadog-log-agent-source`.",
+							Optional:            true,
+						},
+						"grok_parsing_rules": schema.ListAttribute{
+							MarkdownDescription: "The grok parsing rules for `grok-parser` vertices.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+						"dataset_id": schema.StringAttribute{
+							MarkdownDescription: "The dataset ID for sink vertices such as `logs-iceberg-table-sink`.",
+							Optional:            true,
+						},
+						"properties_json": schema.StringAttribute{
+							MarkdownDescription: "Additional vertex fields as a JSON object, for fields without a dedicated attribute. Use `jsonencode()`.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"edge": schema.ListNestedBlock{
+				MarkdownDescription: "A directed edge between two vertices of the job graph. Requires `vertex` blocks.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"from": schema.StringAttribute{
+							MarkdownDescription: "The name of the upstream vertex.",
+							Required:            true,
+						},
+						"to": schema.StringAttribute{
+							MarkdownDescription: "The name of the downstream vertex.",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
 	}
 }
@@ -0,0 +1,102 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grepr-ai/terraform-provider-grepr/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// stubJobsAPI serves the read endpoints of the Grepr jobs API from a fixed
+// list of jobs, so that resources and data sources can be tested against a
+// real *client.Client.
+type stubJobsAPI struct {
+	jobs []client.Job
+
+	// pageSize is the number of jobs per page of the list endpoint. Zero
+	// returns every job on a single page.
+	pageSize int
+
+	// pageRequests counts the requests made to the list endpoint.
+	pageRequests atomic.Int32
+}
+
+// newStubClient starts a server for api and returns a client connected to it.
+func newStubClient(t *testing.T, api *stubJobsAPI) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(server.Close)
+
+	return client.NewClient(client.Config{Host: server.URL, AccessToken: "test-token"})
+}
+
+func (api *stubJobsAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.URL.Path == client.EndpointJobs {
+		api.pageRequests.Add(1)
+
+		// Like the API, the name filter is not an exact match
+		var matched []client.Job
+		for _, job := range api.jobs {
+			if strings.Contains(job.Name, r.URL.Query().Get("name")) {
+				matched = append(matched, job)
+			}
+		}
+
+		offset, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+		end := len(matched)
+		if api.pageSize > 0 && offset+api.pageSize < end {
+			end = offset + api.pageSize
+		}
+		page := matched[min(offset, end):end]
+
+		resp := client.JobsResponse{Items: &page}
+		if end < len(matched) {
+			next := strconv.Itoa(end)
+			resp.NextPageToken = &next
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, client.EndpointJobs+"/")
+	for _, job := range api.jobs {
+		if job.Id == id {
+			_ = json.NewEncoder(w).Encode(job)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNotFound)
+	_, _ = w.Write([]byte(`{"message": "not found"}`))
+}
+
+// testObjectValue returns a value of the object type typ with the given
+// attribute values; all other attributes are null.
+func testObjectValue(ctx context.Context, typ attr.Type, values map[string]tftypes.Value) tftypes.Value {
+	objectType := typ.TerraformType(ctx).(tftypes.Object)
+
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attrs[name] = value
+		} else {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, attrs)
+}